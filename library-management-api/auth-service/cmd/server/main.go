@@ -1,15 +1,21 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"log"
+	"net/http"
 	"time"
 
 	"auth-service/configs"
+	"auth-service/internal/discovery"
 	"auth-service/internal/handler"
+	"auth-service/internal/metrics"
 	"auth-service/internal/middleware"
 	"auth-service/internal/repository"
+	"auth-service/internal/requestid"
+	"auth-service/internal/server"
 	"auth-service/internal/service"
+	"auth-service/internal/tracing"
 	"auth-service/utils"
 
 	"github.com/gin-gonic/gin"
@@ -20,12 +26,22 @@ func main() {
 	// Konfigürasyonu yükle
 	cfg := configs.LoadConfig()
 
-	// Veritabanı bağlantısını oluştur
-	db, err := sql.Open("postgres", cfg.GetDatabaseURL())
+	// Kontrollü kapanma - SIGTERM'de health check DRAINING döner ve devam eden istekler beklenir
+	srv := server.New(cfg.Shutdown)
+
+	// Dağıtık tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "auth-service", cfg.Tracing)
+	if err != nil {
+		log.Fatal("Tracing başlatılamadı:", err)
+	}
+	srv.OnShutdown("Tracing", shutdownTracing)
+
+	// Veritabanı bağlantısını oluştur (SQL sorguları için span açar)
+	db, err := tracing.OpenDB("postgres", cfg.GetDatabaseURL())
 	if err != nil {
 		log.Fatal("Veritabanı bağlantısı açılamadı:", err)
 	}
-	defer db.Close()
+	srv.OnShutdown("Veritabanı bağlantı havuzu", func(context.Context) error { return db.Close() })
 
 	// Bağlantıyı test et
 	if err := db.Ping(); err != nil {
@@ -40,6 +56,9 @@ func main() {
 		log.Fatal("Geçersiz token süresi:", err)
 	}
 
+	// Service registry - REGISTRY_URL tanımlıysa instance gateway'deki registry'ye kaydolur
+	discoveryClient := discovery.NewClient("auth-service", cfg.Discovery)
+
 	// Dependency Injection - katmanlarını oluştur
 	userRepo := repository.NewPostgreSQLUserRepository(db)
 	jwtManager := utils.NewJWTManager(cfg.JWT.SecretKey, tokenDuration)
@@ -48,13 +67,20 @@ func main() {
 	authMiddleware := middleware.NewAuthMiddleware(authService)
 
 	// Gin router'ını oluştur
-	r := gin.Default()
-
-	// CORS middleware ekle
-	r.Use(middleware.CORSMiddleware())
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.Use(gin.LoggerWithFormatter(requestid.LogFormatter), gin.Recovery())
+
+	// Prometheus metrikleri
+	if cfg.Metrics.Enabled {
+		serviceMetrics := metrics.New("auth-service")
+		serviceMetrics.RegisterDB(db, cfg.Database.DBName)
+		r.Use(serviceMetrics.Middleware())
+		r.GET(cfg.Metrics.Path, gin.WrapH(serviceMetrics.Handler()))
+	}
 
 	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
+	r.GET("/health", srv.Readiness(), func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status":  "OK",
 			"service": "auth-service",
@@ -62,6 +88,9 @@ func main() {
 		})
 	})
 
+	// Dağıtık tracing - health check ve metrics endpoint'leri span üretmez
+	r.Use(tracing.Middleware())
+
 	// API routes - diğer servislerle tutarlılık için /api prefix'i kullan
 	apiRoutes := r.Group("/api")
 	{
@@ -96,10 +125,25 @@ func main() {
 	log.Println("  ✅ GET  /api/auth/validate           - Token doğrulama (Protected)")
 	log.Println("  👥 GET  /api/auth/users/:id          - Kullanıcı bilgisi (Protected)")
 	log.Println("  🩺 GET  /health                     - Health check")
+	if cfg.Metrics.Enabled {
+		log.Printf("  📈 GET  %s - Prometheus metrikleri", cfg.Metrics.Path)
+	}
+	log.Printf("  🔭 Tracing exporter: %s (sample ratio: %.2f)", cfg.Tracing.Exporter, cfg.Tracing.SampleRatio)
+	if discoveryClient.Enabled() {
+		log.Printf("  📇 Service registry: %s (%s olarak, heartbeat %s)", cfg.Discovery.RegistryURL, cfg.Discovery.AdvertiseURL, cfg.Discovery.HeartbeatInterval)
+	}
 	log.Printf("  🔑 JWT Secret: %s", cfg.JWT.SecretKey[:10]+"...")
 	log.Printf("  ⏰ Token Duration: %s", cfg.JWT.TokenDuration)
 
-	if err := r.Run(serverAddr); err != nil {
+	// Registry'ye kaydol ve heartbeat gönder - kapanma başında kayıt silinir, gateway yeni trafik göndermez
+	registryCtx, stopRegistry := context.WithCancel(context.Background())
+	srv.OnDrain("Service registry kaydı silme", func(ctx context.Context) error {
+		stopRegistry()
+		return discoveryClient.Deregister(ctx)
+	})
+	go discoveryClient.Start(registryCtx)
+
+	if err := srv.Run(&http.Server{Addr: serverAddr, Handler: r}); err != nil {
 		log.Fatal("Server başlatılamadı:", err)
 	}
-} 
\ No newline at end of file
+}
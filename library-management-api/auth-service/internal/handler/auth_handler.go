@@ -6,6 +6,7 @@ import (
 
 	"auth-service/internal/middleware"
 	"auth-service/internal/model"
+	"auth-service/internal/requestid"
 	"auth-service/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -36,28 +37,31 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req model.RegisterRequest
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Bad Request",
-			"message": "Geçersiz istek formatı: " + err.Error(),
+			"error":      "Bad Request",
+			"message":    "Geçersiz istek formatı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	response, err := h.authService.Register(&req)
+	response, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
 		if err == model.ErrUserAlreadyExists {
 			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Conflict",
-				"message": "Kullanıcı adı veya e-posta zaten kullanımda",
+				"error":      "Conflict",
+				"message":    "Kullanıcı adı veya e-posta zaten kullanımda",
+				"request_id": requestid.Get(c),
 			})
 			return
 		}
-		
+
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": "Kullanıcı kaydı yapılamadı: " + err.Error(),
+			"error":      "Internal Server Error",
+			"message":    "Kullanıcı kaydı yapılamadı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -79,28 +83,31 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req model.LoginRequest
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Bad Request",
-			"message": "Geçersiz istek formatı: " + err.Error(),
+			"error":      "Bad Request",
+			"message":    "Geçersiz istek formatı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	response, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
 		if err == model.ErrInvalidCredentials {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Geçersiz kullanıcı adı veya şifre",
+				"error":      "Unauthorized",
+				"message":    "Geçersiz kullanıcı adı veya şifre",
+				"request_id": requestid.Get(c),
 			})
 			return
 		}
-		
+
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": "Giriş yapılamadı: " + err.Error(),
+			"error":      "Internal Server Error",
+			"message":    "Giriş yapılamadı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -123,25 +130,28 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "Unauthorized",
-			"message": "Kullanıcı bilgisi bulunamadı",
+			"error":      "Unauthorized",
+			"message":    "Kullanıcı bilgisi bulunamadı",
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	user, err := h.authService.GetUserByID(userID)
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
 		if err == model.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Not Found",
-				"message": "Kullanıcı bulunamadı",
+				"error":      "Not Found",
+				"message":    "Kullanıcı bulunamadı",
+				"request_id": requestid.Get(c),
 			})
 			return
 		}
-		
+
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": "Kullanıcı bilgisi getirilemedi: " + err.Error(),
+			"error":      "Internal Server Error",
+			"message":    "Kullanıcı bilgisi getirilemedi: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -166,8 +176,9 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "Unauthorized",
-			"message": "Kullanıcı bilgisi bulunamadı",
+			"error":      "Unauthorized",
+			"message":    "Kullanıcı bilgisi bulunamadı",
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -176,28 +187,31 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		OldPassword string `json:"old_password" binding:"required"`
 		NewPassword string `json:"new_password" binding:"required,min=6"`
 	}
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Bad Request",
-			"message": "Geçersiz istek formatı: " + err.Error(),
+			"error":      "Bad Request",
+			"message":    "Geçersiz istek formatı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	err := h.authService.ChangePassword(userID, req.OldPassword, req.NewPassword)
+	err := h.authService.ChangePassword(c.Request.Context(), userID, req.OldPassword, req.NewPassword)
 	if err != nil {
 		if err == model.ErrInvalidCredentials {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Mevcut şifre yanlış",
+				"error":      "Unauthorized",
+				"message":    "Mevcut şifre yanlış",
+				"request_id": requestid.Get(c),
 			})
 			return
 		}
-		
+
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": "Şifre değiştirilemedi: " + err.Error(),
+			"error":      "Internal Server Error",
+			"message":    "Şifre değiştirilemedi: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -223,20 +237,22 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req struct {
 		Token string `json:"token" binding:"required"`
 	}
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Bad Request",
-			"message": "Geçersiz istek formatı: " + err.Error(),
+			"error":      "Bad Request",
+			"message":    "Geçersiz istek formatı: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	newToken, err := h.authService.RefreshToken(req.Token)
+	newToken, err := h.authService.RefreshToken(c.Request.Context(), req.Token)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "Unauthorized",
-			"message": "Token yenilenemedi: " + err.Error(),
+			"error":      "Unauthorized",
+			"message":    "Token yenilenemedi: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -259,8 +275,9 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "Unauthorized",
-			"message": "Token geçersiz",
+			"error":      "Unauthorized",
+			"message":    "Token geçersiz",
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
@@ -294,28 +311,31 @@ func (h *AuthHandler) GetUser(c *gin.Context) {
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Bad Request",
-			"message": "Geçersiz kullanıcı ID",
+			"error":      "Bad Request",
+			"message":    "Geçersiz kullanıcı ID",
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
-	user, err := h.authService.GetUserByID(uint(id))
+	user, err := h.authService.GetUserByID(c.Request.Context(), uint(id))
 	if err != nil {
 		if err == model.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Not Found",
-				"message": "Kullanıcı bulunamadı",
+				"error":      "Not Found",
+				"message":    "Kullanıcı bulunamadı",
+				"request_id": requestid.Get(c),
 			})
 			return
 		}
-		
+
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": "Kullanıcı bilgisi getirilemedi: " + err.Error(),
+			"error":      "Internal Server Error",
+			"message":    "Kullanıcı bilgisi getirilemedi: " + err.Error(),
+			"request_id": requestid.Get(c),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, user.ToResponse())
-} 
\ No newline at end of file
+}
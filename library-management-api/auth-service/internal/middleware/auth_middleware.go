@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"auth-service/internal/requestid"
 	"auth-service/internal/service"
 	"auth-service/utils"
 	"github.com/gin-gonic/gin"
@@ -27,8 +28,9 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authorization header bulunamadı",
+				"error":      "Unauthorized",
+				"message":    "Authorization header bulunamadı",
+				"request_id": requestid.Get(c),
 			})
 			c.Abort()
 			return
@@ -38,19 +40,21 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		token, err := utils.ExtractTokenFromHeader(authHeader)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Geçersiz authorization header formatı",
+				"error":      "Unauthorized",
+				"message":    "Geçersiz authorization header formatı",
+				"request_id": requestid.Get(c),
 			})
 			c.Abort()
 			return
 		}
 
 		// Token'ı doğrula
-		claims, err := m.authService.ValidateToken(token)
+		claims, err := m.authService.ValidateToken(c.Request.Context(), token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized", 
-				"message": "Geçersiz veya süresi dolmuş token",
+				"error":      "Unauthorized",
+				"message":    "Geçersiz veya süresi dolmuş token",
+				"request_id": requestid.Get(c),
 			})
 			c.Abort()
 			return
@@ -85,7 +89,7 @@ func (m *AuthMiddleware) RequireAuthOptional() gin.HandlerFunc {
 		}
 
 		// Token'ı doğrula
-		claims, err := m.authService.ValidateToken(token)
+		claims, err := m.authService.ValidateToken(c.Request.Context(), token)
 		if err != nil {
 			// Geçersiz token, devam et
 			c.Next()
@@ -101,30 +105,13 @@ func (m *AuthMiddleware) RequireAuthOptional() gin.HandlerFunc {
 	}
 }
 
-// CORS middleware'ı
-func CORSMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
-	}
-}
-
 // GetUserID context'ten user ID'yi alır
 func GetUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		return 0, false
 	}
-	
+
 	id, ok := userID.(uint)
 	return id, ok
 }
@@ -135,7 +122,7 @@ func GetUsername(c *gin.Context) (string, bool) {
 	if !exists {
 		return "", false
 	}
-	
+
 	name, ok := username.(string)
 	return name, ok
 }
@@ -146,7 +133,7 @@ func GetEmail(c *gin.Context) (string, bool) {
 	if !exists {
 		return "", false
 	}
-	
+
 	mail, ok := email.(string)
 	return mail, ok
-} 
\ No newline at end of file
+}
@@ -11,23 +11,24 @@ var (
 	ErrInvalidUsername    = errors.New("geçersiz kullanıcı adı")
 	ErrInvalidEmail       = errors.New("geçersiz e-posta adresi")
 	ErrWeakPassword       = errors.New("şifre çok zayıf")
-	
+
 	// Token hataları
-	ErrInvalidToken       = errors.New("geçersiz token")
-	ErrExpiredToken       = errors.New("token süresi dolmuş")
-	ErrMissingToken       = errors.New("token bulunamadı")
-	ErrTokenGeneration    = errors.New("token oluşturulamadı")
-	
+	ErrInvalidToken    = errors.New("geçersiz token")
+	ErrExpiredToken    = errors.New("token süresi dolmuş")
+	ErrMissingToken    = errors.New("token bulunamadı")
+	ErrTokenGeneration = errors.New("token oluşturulamadı")
+
 	// Genel hatalar
-	ErrInternalServer     = errors.New("sunucu hatası")
-	ErrInvalidRequest     = errors.New("geçersiz istek")
-	ErrUnauthorized       = errors.New("yetkisiz erişim")
-	ErrForbidden          = errors.New("yasaklı erişim")
+	ErrInternalServer = errors.New("sunucu hatası")
+	ErrInvalidRequest = errors.New("geçersiz istek")
+	ErrUnauthorized   = errors.New("yetkisiz erişim")
+	ErrForbidden      = errors.New("yasaklı erişim")
 )
 
 // ErrorResponse API hata yanıt yapısı
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    int    `json:"code,omitempty"`
-} 
\ No newline at end of file
+	Error     string `json:"error"`
+	Message   string `json:"message,omitempty"`
+	Code      int    `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
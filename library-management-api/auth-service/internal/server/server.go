@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"auth-service/configs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// closer kapanma sırasında çalıştırılan kaynak kapatma adımı
+type closer struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Server http.Server'ları SIGINT/SIGTERM gelene kadar çalıştırır ve kontrollü kapatır:
+// readiness draining'e döner, devam eden isteklere süre tanınır, ardından kaynaklar kapatılır
+type Server struct {
+	config   configs.ShutdownConfig
+	draining atomic.Bool
+	drainers []closer
+	closers  []closer
+}
+
+// New yeni server oluşturur
+func New(config configs.ShutdownConfig) *Server {
+	return &Server{config: config}
+}
+
+// Draining servisin kapanma sürecinde olup olmadığını döner
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
+
+// Readiness kapanma başladığında health check'i 503 DRAINING ile yanıtlar; load balancer'lar
+// (gateway health checker dahil) instance'ı yeni trafikten çıkarır
+func (s *Server) Readiness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.Draining() {
+			c.Header("Connection", "close")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"status":  "DRAINING",
+				"message": "Servis kapanıyor, yeni istek kabul edilmiyor",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// OnDrain kapanma başlar başlamaz, drain beklemesinden önce kayıt sırasıyla çalıştırılacak adımı kaydeder;
+// ör. instance'ın service registry'den silinmesi
+func (s *Server) OnDrain(name string, fn func(ctx context.Context) error) {
+	s.drainers = append(s.drainers, closer{name: name, fn: fn})
+}
+
+// OnShutdown kapanmada çalıştırılacak adımı kaydeder. Adımlar defer gibi kayıt sırasının tersine çalışır;
+// önce kaydedilen kaynaklar (ör. tracing) en son kapatılır.
+func (s *Server) OnShutdown(name string, fn func(ctx context.Context) error) {
+	s.closers = append(s.closers, closer{name: name, fn: fn})
+}
+
+// Run server'ları başlatır ve sinyal veya dinleme hatası gelene kadar bekler, ardından kontrollü kapatır.
+// İkinci bir sinyal süreci beklemeden sonlandırır.
+func (s *Server) Run(servers ...*http.Server) error {
+	// İstek context'leri bu context'ten türer; hijack edilmiş bağlantılar (WebSocket) Shutdown'ı beklemediği
+	// için istekler tamamlandıktan sonra iptal edilerek kapatılır
+	base, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
+	errs := make(chan error, len(servers))
+	for _, srv := range servers {
+		if srv.BaseContext == nil {
+			srv.BaseContext = func(net.Listener) context.Context { return base }
+		}
+		go func(srv *http.Server) {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("%s: %w", srv.Addr, err)
+			}
+		}(srv)
+	}
+
+	signals, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	var runErr error
+	select {
+	case <-signals.Done():
+		log.Println("🛑 Kapanma sinyali alındı, servis draining durumuna geçiyor")
+	case runErr = <-errs:
+		log.Printf("❌ Server çalışmayı durdurdu: %v", runErr)
+	}
+	stop()
+	s.draining.Store(true)
+	s.drain()
+
+	if runErr == nil && s.config.DrainDelay > 0 {
+		log.Printf("⏳ Load balancer'ların instance'ı trafikten çıkarması için %s bekleniyor", s.config.DrainDelay)
+		time.Sleep(s.config.DrainDelay)
+	}
+
+	s.shutdown(servers)
+	cancelBase()
+	s.close()
+
+	log.Println("👋 Servis kapatıldı")
+	return runErr
+}
+
+// shutdown yeni bağlantıları keser ve devam eden isteklerin tamamlanmasını Timeout kadar bekler;
+// süre dolarsa kalan bağlantılar zorla kapatılır
+func (s *Server) shutdown(servers []*http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	log.Printf("🚦 Devam eden istekler için en fazla %s bekleniyor", s.config.Timeout)
+	var wg sync.WaitGroup
+	for _, srv := range servers {
+		wg.Add(1)
+		go func(srv *http.Server) {
+			defer wg.Done()
+			if err := srv.Shutdown(ctx); err != nil {
+				log.Printf("⚠️ %s: istekler süresinde tamamlanmadı, bağlantılar kapatılıyor: %v", srv.Addr, err)
+				srv.Close()
+			}
+		}(srv)
+	}
+	wg.Wait()
+}
+
+// drain kayıtlı drain adımlarını sırayla çalıştırır; her adıma ayrı Timeout tanınır
+func (s *Server) drain() {
+	for _, step := range s.drainers {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+		if err := step.fn(ctx); err != nil {
+			log.Printf("⚠️ %s başarısız: %v", step.name, err)
+		} else {
+			log.Printf("🚪 %s tamamlandı", step.name)
+		}
+		cancel()
+	}
+}
+
+// close kayıtlı kapatma adımlarını ters sırayla çalıştırır; her adıma ayrı Timeout tanınır
+func (s *Server) close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		step := s.closers[i]
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+		if err := step.fn(ctx); err != nil {
+			log.Printf("⚠️ %s kapatılamadı: %v", step.name, err)
+		} else {
+			log.Printf("🧹 %s kapatıldı", step.name)
+		}
+		cancel()
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	"auth-service/internal/model"
@@ -10,12 +11,12 @@ import (
 
 // AuthService authentication service interface'i
 type AuthService interface {
-	Register(req *model.RegisterRequest) (*model.RegisterResponse, error)
-	Login(req *model.LoginRequest) (*model.LoginResponse, error)
-	ValidateToken(token string) (*model.JWTClaims, error)
-	GetUserByID(userID uint) (*model.User, error)
-	ChangePassword(userID uint, oldPassword, newPassword string) error
-	RefreshToken(token string) (string, error)
+	Register(ctx context.Context, req *model.RegisterRequest) (*model.RegisterResponse, error)
+	Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error)
+	ValidateToken(ctx context.Context, token string) (*model.JWTClaims, error)
+	GetUserByID(ctx context.Context, userID uint) (*model.User, error)
+	ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error
+	RefreshToken(ctx context.Context, token string) (string, error)
 }
 
 // authService authentication service implementasyonu
@@ -33,14 +34,14 @@ func NewAuthService(userRepo repository.UserRepository, jwtManager *utils.JWTMan
 }
 
 // Register yeni kullanıcı kaydı yapar
-func (s *authService) Register(req *model.RegisterRequest) (*model.RegisterResponse, error) {
+func (s *authService) Register(ctx context.Context, req *model.RegisterRequest) (*model.RegisterResponse, error) {
 	// Şifre gücü kontrolü
 	if err := utils.ValidatePasswordStrength(req.Password); err != nil {
 		return nil, fmt.Errorf("şifre geçersiz: %w", err)
 	}
 
 	// Kullanıcı adı kontrolü
-	exists, err := s.userRepo.ExistsByUsername(req.Username)
+	exists, err := s.userRepo.ExistsByUsername(ctx, req.Username)
 	if err != nil {
 		return nil, fmt.Errorf("kullanıcı adı kontrolü yapılamadı: %w", err)
 	}
@@ -49,7 +50,7 @@ func (s *authService) Register(req *model.RegisterRequest) (*model.RegisterRespo
 	}
 
 	// E-posta kontrolü
-	exists, err = s.userRepo.ExistsByEmail(req.Email)
+	exists, err = s.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, fmt.Errorf("e-posta kontrolü yapılamadı: %w", err)
 	}
@@ -70,7 +71,7 @@ func (s *authService) Register(req *model.RegisterRequest) (*model.RegisterRespo
 		Password: hashedPassword,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("kullanıcı oluşturulamadı: %w", err)
 	}
 
@@ -81,9 +82,9 @@ func (s *authService) Register(req *model.RegisterRequest) (*model.RegisterRespo
 }
 
 // Login kullanıcı girişi yapar
-func (s *authService) Login(req *model.LoginRequest) (*model.LoginResponse, error) {
+func (s *authService) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
 	// Kullanıcıyı bul
-	user, err := s.userRepo.GetByUsername(req.Username)
+	user, err := s.userRepo.GetByUsername(ctx, req.Username)
 	if err != nil {
 		if err == model.ErrUserNotFound {
 			return nil, model.ErrInvalidCredentials
@@ -109,14 +110,14 @@ func (s *authService) Login(req *model.LoginRequest) (*model.LoginResponse, erro
 }
 
 // ValidateToken token'ı doğrular
-func (s *authService) ValidateToken(token string) (*model.JWTClaims, error) {
+func (s *authService) ValidateToken(ctx context.Context, token string) (*model.JWTClaims, error) {
 	claims, err := s.jwtManager.VerifyToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("token doğrulanamadı: %w", err)
 	}
 
 	// Kullanıcının hala mevcut olup olmadığını kontrol et
-	_, err = s.userRepo.GetByID(claims.UserID)
+	_, err = s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		if err == model.ErrUserNotFound {
 			return nil, model.ErrInvalidToken
@@ -128,8 +129,8 @@ func (s *authService) ValidateToken(token string) (*model.JWTClaims, error) {
 }
 
 // GetUserByID kullanıcıyı ID'ye göre getirir
-func (s *authService) GetUserByID(userID uint) (*model.User, error) {
-	user, err := s.userRepo.GetByID(userID)
+func (s *authService) GetUserByID(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("kullanıcı getirilemedi: %w", err)
 	}
@@ -138,9 +139,9 @@ func (s *authService) GetUserByID(userID uint) (*model.User, error) {
 }
 
 // ChangePassword kullanıcının şifresini değiştirir
-func (s *authService) ChangePassword(userID uint, oldPassword, newPassword string) error {
+func (s *authService) ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error {
 	// Mevcut kullanıcıyı getir
-	user, err := s.userRepo.GetByID(userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("kullanıcı bulunamadı: %w", err)
 	}
@@ -163,7 +164,7 @@ func (s *authService) ChangePassword(userID uint, oldPassword, newPassword strin
 
 	// Şifreyi güncelle
 	user.Password = hashedPassword
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(ctx, user); err != nil {
 		return fmt.Errorf("şifre güncellenemedi: %w", err)
 	}
 
@@ -171,7 +172,7 @@ func (s *authService) ChangePassword(userID uint, oldPassword, newPassword strin
 }
 
 // RefreshToken token'ı yeniler
-func (s *authService) RefreshToken(token string) (string, error) {
+func (s *authService) RefreshToken(ctx context.Context, token string) (string, error) {
 	// Mevcut token'ı doğrula
 	claims, err := s.jwtManager.VerifyToken(token)
 	if err != nil {
@@ -179,7 +180,7 @@ func (s *authService) RefreshToken(token string) (string, error) {
 	}
 
 	// Kullanıcıyı getir
-	user, err := s.userRepo.GetByID(claims.UserID)
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return "", fmt.Errorf("kullanıcı bulunamadı: %w", err)
 	}
@@ -191,4 +192,4 @@ func (s *authService) RefreshToken(token string) (string, error) {
 	}
 
 	return newToken, nil
-} 
\ No newline at end of file
+}
@@ -0,0 +1,168 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"auth-service/configs"
+	"auth-service/internal/requestid"
+
+	"github.com/XSAM/otelsql"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Desteklenen span exporter'ları
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// tracerName servis içinde açılan span'lerin instrumentation adı
+const tracerName = "auth-service/internal/tracing"
+
+// ShutdownFunc bekleyen span'leri exporter'a gönderip tracer provider'ı kapatır
+type ShutdownFunc func(ctx context.Context) error
+
+// Init global W3C traceparent propagator'ını ve konfigürasyondaki exporter ile tracer provider'ı kurar.
+// Exporter "none" ise span kaydedilmez ancak gelen traceparent downstream servislere aktarılmaya devam eder.
+func Init(ctx context.Context, service string, cfg configs.TracingConfig) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(service)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource oluşturulamadı: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// newExporter konfigürasyondaki exporter'ı oluşturur; dosya exporter'ı için kapatılması gereken dosyayı da döner
+func newExporter(ctx context.Context, cfg configs.TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return nil, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("trace dosyası açılamadı: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		return exporter, nil, err
+	default:
+		return nil, nil, fmt.Errorf("bilinmeyen tracing exporter: %q", cfg.Exporter)
+	}
+}
+
+// Middleware gelen traceparent'ı devralarak her istek için route şablonuyla adlandırılmış server span'i açar
+func Middleware() gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		name := c.Request.Method
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.URLPath(c.Request.URL.Path),
+			semconv.ClientAddress(c.ClientIP()),
+			attribute.String("request.id", requestid.Get(c)),
+		}
+		if route := c.FullPath(); route != "" {
+			name += " " + route
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+
+		ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// Transport giden isteklere client span'i açan ve traceparent header'ını ekleyen RoundTripper döner
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return "HTTP " + r.Method + " " + r.URL.Host
+	}))
+}
+
+// OpenDB SQL sorguları için span açan veritabanı bağlantısı oluşturur. Span'ler yalnızca bir istek
+// içinde (parent span varken) açılır; ping ve bağlantı havuzu işleri tek başına trace üretmez.
+func OpenDB(driverName, dataSourceName string) (*sql.DB, error) {
+	return otelsql.Open(driverName, dataSourceName,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}),
+	)
+}
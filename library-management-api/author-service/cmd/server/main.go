@@ -1,13 +1,19 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"log"
+	"net/http"
 
 	"author-service/configs"
+	"author-service/internal/discovery"
 	"author-service/internal/handler"
+	"author-service/internal/metrics"
 	"author-service/internal/repository"
+	"author-service/internal/requestid"
+	"author-service/internal/server"
 	"author-service/internal/service"
+	"author-service/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
@@ -17,12 +23,22 @@ func main() {
 	// Konfigürasyonu yükle
 	cfg := configs.LoadConfig()
 
-	// Veritabanı bağlantısını oluştur
-	db, err := sql.Open("postgres", cfg.GetDatabaseURL())
+	// Kontrollü kapanma - SIGTERM'de health check DRAINING döner ve devam eden istekler beklenir
+	srv := server.New(cfg.Shutdown)
+
+	// Dağıtık tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "author-service", cfg.Tracing)
+	if err != nil {
+		log.Fatal("Tracing başlatılamadı:", err)
+	}
+	srv.OnShutdown("Tracing", shutdownTracing)
+
+	// Veritabanı bağlantısını oluştur (SQL sorguları için span açar)
+	db, err := tracing.OpenDB("postgres", cfg.GetDatabaseURL())
 	if err != nil {
 		log.Fatal("Veritabanı bağlantısı açılamadı:", err)
 	}
-	defer db.Close()
+	srv.OnShutdown("Veritabanı bağlantı havuzu", func(context.Context) error { return db.Close() })
 
 	// Bağlantıyı test et
 	if err := db.Ping(); err != nil {
@@ -31,17 +47,30 @@ func main() {
 
 	log.Println("Author servisi PostgreSQL veritabanına başarıyla bağlandı")
 
+	// Service registry - diğer servislerin instance'ları isimle çözülür, REGISTRY_URL yoksa statik URL'ler kullanılır
+	discoveryClient := discovery.NewClient("author-service", cfg.Discovery)
+
 	// Dependency Injection -    katmanlarını oluştur
 	authorRepo := repository.NewPostgreSQLAuthorRepository(db)
-	bookService := service.NewHTTPBookService(cfg.Services.BookServiceURL)
+	bookService := service.NewHTTPBookService(discoveryClient.Endpoint("book-service", cfg.Services.BookServiceURL))
 	authorService := service.NewAuthorService(authorRepo, bookService)
 	authorHandler := handler.NewAuthorHandler(authorService)
 
 	// Gin router'ını oluştur
-	r := gin.Default()
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.Use(gin.LoggerWithFormatter(requestid.LogFormatter), gin.Recovery())
+
+	// Prometheus metrikleri
+	if cfg.Metrics.Enabled {
+		serviceMetrics := metrics.New("author-service")
+		serviceMetrics.RegisterDB(db, cfg.Database.DBName)
+		r.Use(serviceMetrics.Middleware())
+		r.GET(cfg.Metrics.Path, gin.WrapH(serviceMetrics.Handler()))
+	}
 
 	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
+	r.GET("/health", srv.Readiness(), func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status":  "OK",
 			"service": "author-service",
@@ -49,6 +78,9 @@ func main() {
 		})
 	})
 
+	// Dağıtık tracing - health check ve metrics endpoint'leri span üretmez
+	r.Use(tracing.Middleware())
+
 	// API endpoint'leri - diğer servislerle tutarlılık için /api prefix'i kullan
 	apiRoutes := r.Group("/api")
 	{
@@ -67,8 +99,23 @@ func main() {
 	log.Println("  ✍️  GET /api/authors/search?name=...    - Yazar arama")
 	log.Println("  ✍️  GET /api/authors/detail/:name       - Yazar detayı + kitapları")
 	log.Println("  🩺 GET /health                         - Health check")
-	
-	if err := r.Run(serverAddr); err != nil {
+	if cfg.Metrics.Enabled {
+		log.Printf("  📈 GET %s - Prometheus metrikleri", cfg.Metrics.Path)
+	}
+	log.Printf("  🔭 Tracing exporter: %s (sample ratio: %.2f)", cfg.Tracing.Exporter, cfg.Tracing.SampleRatio)
+	if discoveryClient.Enabled() {
+		log.Printf("  📇 Service registry: %s (%s olarak, heartbeat %s)", cfg.Discovery.RegistryURL, cfg.Discovery.AdvertiseURL, cfg.Discovery.HeartbeatInterval)
+	}
+
+	// Registry'ye kaydol ve heartbeat gönder - kapanma başında kayıt silinir, gateway yeni trafik göndermez
+	registryCtx, stopRegistry := context.WithCancel(context.Background())
+	srv.OnDrain("Service registry kaydı silme", func(ctx context.Context) error {
+		stopRegistry()
+		return discoveryClient.Deregister(ctx)
+	})
+	go discoveryClient.Start(registryCtx)
+
+	if err := srv.Run(&http.Server{Addr: serverAddr, Handler: r}); err != nil {
 		log.Fatal("Server başlatılamadı:", err)
 	}
-} 
\ No newline at end of file
+}
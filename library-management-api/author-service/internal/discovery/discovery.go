@@ -0,0 +1,279 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"author-service/configs"
+)
+
+// TokenHeader gateway'deki service registry'nin beklediği token header'ı
+const TokenHeader = "X-Registry-Token"
+
+// defaultInterval heartbeat veya yenileme aralığı tanımlı değilse kullanılan süre
+const defaultInterval = 10 * time.Second
+
+// Client instance'ı gateway'deki service registry'ye kaydeder, heartbeat gönderir ve diğer servislerin
+// sağlıklı instance'larını isimle çözer. REGISTRY_URL tanımlı değilse sadece statik URL'ler kullanılır.
+type Client struct {
+	service    string
+	config     configs.DiscoveryConfig
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	id           string
+	deregistered bool
+	endpoints    map[string]*Endpoint
+}
+
+// NewClient verilen servis adı için discovery client'ı oluşturur
+func NewClient(service string, config configs.DiscoveryConfig) *Client {
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = defaultInterval
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultInterval
+	}
+	config.RegistryURL = strings.TrimSuffix(config.RegistryURL, "/")
+
+	return &Client{
+		service:    service,
+		config:     config,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		endpoints:  make(map[string]*Endpoint),
+	}
+}
+
+// Enabled registry'nin kullanılıp kullanılmadığını döner
+func (c *Client) Enabled() bool {
+	return c.config.RegistryURL != ""
+}
+
+// Endpoint servisin instance adreslerini çözen endpoint'i döner; registry'de sağlıklı instance yoksa
+// veya registry kapalıysa fallbackURL kullanılır
+func (c *Client) Endpoint(service, fallbackURL string) *Endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if endpoint, ok := c.endpoints[service]; ok {
+		return endpoint
+	}
+	endpoint := &Endpoint{service: service, fallback: strings.TrimSuffix(fallbackURL, "/")}
+	c.endpoints[service] = endpoint
+	return endpoint
+}
+
+// Start instance'ı kaydeder ve context iptal edilene kadar heartbeat gönderip endpoint'leri yeniler.
+// Registry'ye ulaşılamazsa servis statik URL'lerle çalışmaya devam eder ve kayıt tekrar denenir.
+func (c *Client) Start(ctx context.Context) {
+	if !c.Enabled() {
+		return
+	}
+
+	c.heartbeat(ctx)
+	c.refresh(ctx)
+
+	heartbeat := time.NewTicker(c.config.HeartbeatInterval)
+	defer heartbeat.Stop()
+	refresh := time.NewTicker(c.config.RefreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			c.heartbeat(ctx)
+		case <-refresh.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+// Deregister instance'ın kaydını siler; kapanma başında çağrılır ve sonrasında heartbeat yeniden kayıt yapmaz
+func (c *Client) Deregister(ctx context.Context) error {
+	c.mu.Lock()
+	id := c.id
+	c.id = ""
+	c.deregistered = true
+	c.mu.Unlock()
+
+	if !c.Enabled() || id == "" {
+		return nil
+	}
+
+	status, err := c.do(ctx, http.MethodDelete, "/registry/instances/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNotFound {
+		return fmt.Errorf("registry kaydı silinemedi: HTTP %d", status)
+	}
+	return nil
+}
+
+// heartbeat kayıtlı instance için heartbeat gönderir; kayıt yoksa veya registry kaydı silmişse yeniden kaydolur
+func (c *Client) heartbeat(ctx context.Context) {
+	c.mu.Lock()
+	id, deregistered := c.id, c.deregistered
+	c.mu.Unlock()
+
+	if deregistered {
+		return
+	}
+	if id != "" {
+		status, err := c.do(ctx, http.MethodPut, "/registry/instances/"+url.PathEscape(id)+"/heartbeat", nil, nil)
+		switch {
+		case err != nil:
+			log.Printf("⚠️ Service registry'ye heartbeat gönderilemedi: %v", err)
+			return
+		case status == http.StatusOK:
+			return
+		case status != http.StatusNotFound:
+			log.Printf("⚠️ Service registry heartbeat'i reddetti: HTTP %d", status)
+			return
+		}
+		log.Println("🔁 Registry kaydı bulunamadı, yeniden kaydolunuyor")
+	}
+	c.register(ctx)
+}
+
+// register instance'ı servis adı ve duyurulan adresle registry'ye kaydeder
+func (c *Client) register(ctx context.Context) {
+	request := map[string]string{"service": c.service, "url": c.config.AdvertiseURL}
+	var response struct {
+		Data struct {
+			Instance struct {
+				ID string `json:"id"`
+			} `json:"instance"`
+			TTL string `json:"ttl"`
+		} `json:"data"`
+	}
+
+	status, err := c.do(ctx, http.MethodPost, "/registry/instances", request, &response)
+	if err != nil {
+		log.Printf("⚠️ Service registry'ye kaydolunamadı, tekrar denenecek: %v", err)
+		return
+	}
+	if status != http.StatusCreated {
+		log.Printf("⚠️ Service registry kaydı reddetti: HTTP %d", status)
+		return
+	}
+
+	c.mu.Lock()
+	if !c.deregistered {
+		c.id = response.Data.Instance.ID
+	}
+	c.mu.Unlock()
+	log.Printf("📇 Service registry'ye kaydolundu: %s -> %s (TTL %s)", c.service, c.config.AdvertiseURL, response.Data.TTL)
+}
+
+// refresh endpoint'lerin sağlıklı instance listelerini registry'den günceller;
+// registry'ye ulaşılamazsa son bilinen liste kullanılmaya devam eder
+func (c *Client) refresh(ctx context.Context) {
+	c.mu.Lock()
+	endpoints := make([]*Endpoint, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	c.mu.Unlock()
+
+	for _, endpoint := range endpoints {
+		var response struct {
+			Data struct {
+				Instances []struct {
+					URL      string `json:"url"`
+					Healthy  bool   `json:"healthy"`
+					Draining bool   `json:"draining"`
+				} `json:"instances"`
+			} `json:"data"`
+		}
+
+		status, err := c.do(ctx, http.MethodGet, "/registry/services/"+url.PathEscape(endpoint.service), nil, &response)
+		if err != nil || status != http.StatusOK {
+			log.Printf("⚠️ %s instance'ları registry'den alınamadı (status %d): %v", endpoint.service, status, err)
+			continue
+		}
+
+		var urls []string
+		for _, instance := range response.Data.Instances {
+			if instance.Healthy && !instance.Draining {
+				urls = append(urls, instance.URL)
+			}
+		}
+		endpoint.set(urls)
+	}
+}
+
+// do registry'ye token'lı JSON isteği gönderir ve durum kodunu döner; out verilmişse yanıt body'si çözülür
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) (int, error) {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return 0, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.config.RegistryURL+path, &body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(TokenHeader, c.config.RegistryToken)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("registry yanıtı çözülemedi: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// Endpoint bir servisin registry'den çözülen instance adresleri; istekler instance'lar arasında sırayla dağıtılır
+type Endpoint struct {
+	service  string
+	fallback string
+	urls     atomic.Pointer[[]string]
+	next     atomic.Uint64
+}
+
+// URL isteğin gönderileceği instance adresini döner; registry'de sağlıklı instance yoksa statik URL döner
+func (e *Endpoint) URL() string {
+	urls := e.urls.Load()
+	if urls == nil || len(*urls) == 0 {
+		return e.fallback
+	}
+	return (*urls)[(e.next.Add(1)-1)%uint64(len(*urls))]
+}
+
+// set registry'den alınan instance listesini değiştirir; liste değiştiğinde log yazılır
+func (e *Endpoint) set(urls []string) {
+	previous := e.urls.Load()
+	if previous != nil && strings.Join(*previous, ",") == strings.Join(urls, ",") {
+		return
+	}
+	e.urls.Store(&urls)
+
+	if len(urls) == 0 {
+		log.Printf("🔀 %s için registry'de sağlıklı instance yok, statik adres kullanılıyor: %s", e.service, e.fallback)
+		return
+	}
+	log.Printf("🔀 %s instance'ları registry'den güncellendi: %v", e.service, urls)
+}
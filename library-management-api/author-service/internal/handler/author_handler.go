@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"author-service/internal/model"
+	"author-service/internal/requestid"
 	"author-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,7 @@ func (h *AuthorHandler) GetAuthors(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authorService.GetPaginatedAuthors(params)
+	result, err := h.authorService.GetPaginatedAuthors(c.Request.Context(), params)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_AUTHORS_ERROR", "Yazarlar getirilemedi")
 		return
@@ -56,9 +57,9 @@ func (h *AuthorHandler) GetAuthorByID(c *gin.Context) {
 
 	// Mock yazar bilgisi (gerçekte DB'den gelecek)
 	authorName := "Yazar " + idParam
-	
+
 	// Zenginleştirilmiş yazar bilgisini al
-	enrichedAuthor, err := h.authorService.GetEnrichedAuthorByName(authorName)
+	enrichedAuthor, err := h.authorService.GetEnrichedAuthorByName(c.Request.Context(), authorName)
 	if err != nil {
 		if err == model.ErrAuthorNotFound {
 			h.respondError(c, http.StatusNotFound, "AUTHOR_NOT_FOUND", "Yazar bulunamadı")
@@ -85,7 +86,7 @@ func (h *AuthorHandler) GetAuthorDetailByName(c *gin.Context) {
 		return
 	}
 
-	enrichedAuthor, err := h.authorService.GetEnrichedAuthorByName(authorName)
+	enrichedAuthor, err := h.authorService.GetEnrichedAuthorByName(c.Request.Context(), authorName)
 	if err != nil {
 		if err == model.ErrAuthorNotFound {
 			h.respondError(c, http.StatusNotFound, "AUTHOR_NOT_FOUND", "Yazar bulunamadı")
@@ -110,7 +111,7 @@ func (h *AuthorHandler) SearchAuthors(c *gin.Context) {
 		return
 	}
 
-	authors, err := h.authorService.GetAuthorByName(name)
+	authors, err := h.authorService.GetAuthorByName(c.Request.Context(), name)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "SEARCH_AUTHORS_ERROR", "Yazar arama başarısız")
 		return
@@ -152,8 +153,9 @@ func (h *AuthorHandler) respondSuccess(c *gin.Context, data interface{}) {
 func (h *AuthorHandler) respondError(c *gin.Context, statusCode int, errorCode, message string) {
 	c.JSON(statusCode, gin.H{
 		"error": gin.H{
-			"code":    errorCode,
-			"message": message,
+			"code":       errorCode,
+			"message":    message,
+			"request_id": requestid.Get(c),
 		},
 	})
-} 
\ No newline at end of file
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -10,8 +11,8 @@ import (
 
 // AuthorRepository yazar veri erişim interface'i
 type AuthorRepository interface {
-	GetPaginatedAuthors(params *model.AuthorSearchParams) (*model.PaginatedAuthors, error)
-	GetAuthorByName(name string) ([]model.Author, error)
+	GetPaginatedAuthors(ctx context.Context, params *model.AuthorSearchParams) (*model.PaginatedAuthors, error)
+	GetAuthorByName(ctx context.Context, name string) ([]model.Author, error)
 	Close() error
 }
 
@@ -28,7 +29,7 @@ func NewPostgreSQLAuthorRepository(db *sql.DB) AuthorRepository {
 }
 
 // GetPaginatedAuthors sayfalı yazar listesi getirir
-func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(params *model.AuthorSearchParams) (*model.PaginatedAuthors, error) {
+func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(ctx context.Context, params *model.AuthorSearchParams) (*model.PaginatedAuthors, error) {
 	if params.Page < 1 {
 		params.Page = 1
 	}
@@ -41,7 +42,7 @@ func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(params *model.AuthorSea
 	// WHERE şartını hazırla
 	whereClause := ""
 	args := []interface{}{}
-	
+
 	if params.SearchTerm != "" {
 		whereClause = " WHERE LOWER(book_author) LIKE LOWER($1)"
 		args = append(args, "%"+params.SearchTerm+"%")
@@ -50,7 +51,7 @@ func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(params *model.AuthorSea
 	// Toplam sayıyı al
 	countQuery := `SELECT COUNT(DISTINCT book_author) FROM books` + whereClause
 	var total int
-	err := r.db.QueryRow(countQuery, args...).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("toplam author sayısı sorgulanamadı: %v", err)
 	}
@@ -60,10 +61,10 @@ func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(params *model.AuthorSea
 			  FROM books` + whereClause + `
 			  ORDER BY book_author 
 			  LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
-	
+
 	args = append(args, params.PageSize, offset)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("authorlar sorgulanamadı: %v", err)
 	}
@@ -95,13 +96,13 @@ func (r *PostgreSQLAuthorRepository) GetPaginatedAuthors(params *model.AuthorSea
 }
 
 // GetAuthorByName isim ile author arama
-func (r *PostgreSQLAuthorRepository) GetAuthorByName(name string) ([]model.Author, error) {
+func (r *PostgreSQLAuthorRepository) GetAuthorByName(ctx context.Context, name string) ([]model.Author, error) {
 	query := `SELECT DISTINCT book_author 
 			  FROM books
 			  WHERE LOWER(book_author) LIKE LOWER($1)
 			  ORDER BY book_author`
-	
-	rows, err := r.db.Query(query, "%"+name+"%")
+
+	rows, err := r.db.QueryContext(ctx, query, "%"+name+"%")
 	if err != nil {
 		return nil, fmt.Errorf("author arama sorgulanamadı: %v", err)
 	}
@@ -130,4 +131,4 @@ func (r *PostgreSQLAuthorRepository) Close() error {
 		return r.db.Close()
 	}
 	return nil
-} 
\ No newline at end of file
+}
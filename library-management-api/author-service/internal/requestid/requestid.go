@@ -0,0 +1,115 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header istek korelasyonu için kullanılan HTTP header'ı
+const Header = "X-Request-ID"
+
+// ContextKey gin context'inde istek ID'sinin tutulduğu anahtar
+const ContextKey = "request_id"
+
+// maxLength dışarıdan gelen istek ID'si için kabul edilen maksimum uzunluk
+const maxLength = 128
+
+type contextKey struct{}
+
+// New yeni rastgele istek ID'si üretir
+func New() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithContext istek ID'sini context'e ekler
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext context'teki istek ID'sini döner (yoksa boş string)
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Get gin context'indeki istek ID'sini döner
+func Get(c *gin.Context) string {
+	return c.GetString(ContextKey)
+}
+
+// Inject istek ID'sini giden HTTP isteğine ekler
+func Inject(ctx context.Context, req *http.Request) {
+	if id := FromContext(ctx); id != "" {
+		req.Header.Set(Header, id)
+	}
+}
+
+// Printf log satırını istek ID'si ile birlikte yazar
+func Printf(ctx context.Context, format string, args ...interface{}) {
+	if id := FromContext(ctx); id != "" {
+		format += " [request_id=" + id + "]"
+	}
+	log.Printf(format, args...)
+}
+
+// Middleware gelen X-Request-ID'yi kabul eder veya yenisini üretir; ID request context'ine,
+// request header'ına (upstream'e aktarılması için) ve yanıt header'ına yazılır
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if !valid(id) {
+			id = New()
+		}
+
+		c.Set(ContextKey, id)
+		c.Request.Header.Set(Header, id)
+		c.Request = c.Request.WithContext(WithContext(c.Request.Context(), id))
+		c.Header(Header, id)
+
+		c.Next()
+	}
+}
+
+// LogFormatter gin access log'una istek ID'sini ekleyen formatter
+func LogFormatter(param gin.LogFormatterParams) string {
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | request_id=%s\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		param.Request.Header.Get(Header),
+		param.ErrorMessage,
+	)
+}
+
+// valid dışarıdan gelen ID'nin log ve header'lara güvenle yazılabileceğini kontrol eder
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -1,17 +1,18 @@
 package service
 
 import (
-	"log"
+	"context"
 
 	"author-service/internal/model"
 	"author-service/internal/repository"
+	"author-service/internal/requestid"
 )
 
 // AuthorService yazar iş mantığı interface'i
 type AuthorService interface {
-	GetPaginatedAuthors(params *model.AuthorSearchParams) (*model.PaginatedAuthors, error)
-	GetAuthorByName(name string) ([]model.Author, error)
-	GetEnrichedAuthorByName(name string) (*model.EnrichedAuthor, error)
+	GetPaginatedAuthors(ctx context.Context, params *model.AuthorSearchParams) (*model.PaginatedAuthors, error)
+	GetAuthorByName(ctx context.Context, name string) ([]model.Author, error)
+	GetEnrichedAuthorByName(ctx context.Context, name string) (*model.EnrichedAuthor, error)
 }
 
 // AuthorServiceImpl AuthorService implementasyonu
@@ -29,28 +30,28 @@ func NewAuthorService(authorRepo repository.AuthorRepository, bookService BookSe
 }
 
 // GetPaginatedAuthors sayfalı yazar listesi getirir
-func (s *AuthorServiceImpl) GetPaginatedAuthors(params *model.AuthorSearchParams) (*model.PaginatedAuthors, error) {
+func (s *AuthorServiceImpl) GetPaginatedAuthors(ctx context.Context, params *model.AuthorSearchParams) (*model.PaginatedAuthors, error) {
 	// Parametreleri doğrula
 	if err := s.validateSearchParams(params); err != nil {
 		return nil, err
 	}
 
-	return s.authorRepo.GetPaginatedAuthors(params)
+	return s.authorRepo.GetPaginatedAuthors(ctx, params)
 }
 
 // GetAuthorByName isim ile yazar arama
-func (s *AuthorServiceImpl) GetAuthorByName(name string) ([]model.Author, error) {
+func (s *AuthorServiceImpl) GetAuthorByName(ctx context.Context, name string) ([]model.Author, error) {
 	if name == "" {
 		return nil, model.ErrInvalidAuthorName
 	}
 
-	return s.authorRepo.GetAuthorByName(name)
+	return s.authorRepo.GetAuthorByName(ctx, name)
 }
 
 // GetEnrichedAuthorByName kitap bilgisiyle zenginleştirilmiş yazar getirir
-func (s *AuthorServiceImpl) GetEnrichedAuthorByName(name string) (*model.EnrichedAuthor, error) {
+func (s *AuthorServiceImpl) GetEnrichedAuthorByName(ctx context.Context, name string) (*model.EnrichedAuthor, error) {
 	// Önce yazar bilgisini al
-	authors, err := s.GetAuthorByName(name)
+	authors, err := s.GetAuthorByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -63,9 +64,9 @@ func (s *AuthorServiceImpl) GetEnrichedAuthorByName(name string) (*model.Enriche
 	author := authors[0]
 
 	// Book service'den bu yazarın kitaplarını al
-	books, err := s.bookService.GetBooksByAuthor(name)
+	books, err := s.bookService.GetBooksByAuthor(ctx, name)
 	if err != nil {
-		log.Printf("Yazar kitapları alınamadı: %v", err)
+		requestid.Printf(ctx, "Yazar kitapları alınamadı: %v", err)
 		// Hata olsa bile yazar bilgisini döndür
 		books = []model.BookInfo{}
 	}
@@ -78,10 +79,10 @@ func (s *AuthorServiceImpl) validateSearchParams(params *model.AuthorSearchParam
 	if params.Page < 1 {
 		return model.ErrInvalidPage
 	}
-	
+
 	if params.PageSize < 1 || params.PageSize > 100 {
 		return model.ErrInvalidPageSize
 	}
 
 	return nil
-} 
\ No newline at end of file
+}
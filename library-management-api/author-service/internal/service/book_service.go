@@ -1,44 +1,54 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"time"
 
+	"author-service/internal/discovery"
 	"author-service/internal/model"
+	"author-service/internal/requestid"
+	"author-service/internal/tracing"
 )
 
 // BookService book microservice ile iletişim interface'i
 type BookService interface {
-	GetBooksByAuthor(authorName string) ([]model.BookInfo, error)
+	GetBooksByAuthor(ctx context.Context, authorName string) ([]model.BookInfo, error)
 }
 
 // HTTPBookService HTTP üzerinden book service implementasyonu
 type HTTPBookService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-// NewHTTPBookService yeni HTTP book service oluşturur
-func NewHTTPBookService(baseURL string) BookService {
+// NewHTTPBookService yeni HTTP book service oluşturur; her istek endpoint'in çözdüğü instance'a gider
+func NewHTTPBookService(endpoint *discovery.Endpoint) BookService {
 	return &HTTPBookService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
 // GetBooksByAuthor book service'den yazar kitaplarını getirir
-func (s *HTTPBookService) GetBooksByAuthor(authorName string) ([]model.BookInfo, error) {
-	url := fmt.Sprintf("%s/api/books/author/%s", s.baseURL, authorName)
-	
-	log.Printf("Book service'e istek gönderiliyor: %s", url)
-	
-	resp, err := s.httpClient.Get(url)
+func (s *HTTPBookService) GetBooksByAuthor(ctx context.Context, authorName string) ([]model.BookInfo, error) {
+	url := fmt.Sprintf("%s/api/books/author/%s", s.endpoint.URL(), authorName)
+
+	requestid.Printf(ctx, "Book service'e istek gönderiliyor: %s", url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, model.NewAuthorError("BOOK_SERVICE_ERROR", "Book service isteği oluşturulamadı", err)
+	}
+	requestid.Inject(ctx, req)
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, model.NewAuthorError("BOOK_SERVICE_ERROR", "Book service'e bağlanamadı", err)
 	}
@@ -56,10 +66,10 @@ func (s *HTTPBookService) GetBooksByAuthor(authorName string) ([]model.BookInfo,
 	var bookResponse struct {
 		Data []model.BookInfo `json:"data"`
 	}
-	
+
 	if err := json.Unmarshal(body, &bookResponse); err != nil {
 		return nil, model.NewAuthorError("BOOK_SERVICE_PARSE_ERROR", "Book service yanıtı parse edilemedi", err)
 	}
 
 	return bookResponse.Data, nil
-} 
\ No newline at end of file
+}
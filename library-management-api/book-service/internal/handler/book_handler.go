@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"book-service/internal/model"
+	"book-service/internal/requestid"
 	"book-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,7 @@ func (h *BookHandler) GetBooks(c *gin.Context) {
 		return
 	}
 
-	result, err := h.bookService.GetPaginatedBooks(params)
+	result, err := h.bookService.GetPaginatedBooks(c.Request.Context(), params)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_BOOKS_ERROR", "Kitaplar getirilemedi")
 		return
@@ -48,7 +49,7 @@ func (h *BookHandler) GetBookByID(c *gin.Context) {
 		return
 	}
 
-	book, err := h.bookService.GetBookByID(id)
+	book, err := h.bookService.GetBookByID(c.Request.Context(), id)
 	if err != nil {
 		if err == model.ErrBookNotFound {
 			h.respondError(c, http.StatusNotFound, "BOOK_NOT_FOUND", "Kitap bulunamadı")
@@ -70,7 +71,7 @@ func (h *BookHandler) GetEnrichedBookByID(c *gin.Context) {
 		return
 	}
 
-	enrichedBook, err := h.bookService.GetEnrichedBookByID(id)
+	enrichedBook, err := h.bookService.GetEnrichedBookByID(c.Request.Context(), id)
 	if err != nil {
 		if err == model.ErrBookNotFound {
 			h.respondError(c, http.StatusNotFound, "BOOK_NOT_FOUND", "Kitap bulunamadı")
@@ -91,7 +92,7 @@ func (h *BookHandler) GetBooksByAuthor(c *gin.Context) {
 		return
 	}
 
-	books, err := h.bookService.GetBooksByAuthor(authorName)
+	books, err := h.bookService.GetBooksByAuthor(c.Request.Context(), authorName)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_AUTHOR_BOOKS_ERROR", "Yazar kitapları getirilemedi")
 		return
@@ -123,7 +124,7 @@ func (h *BookHandler) GetBooksByCategory(c *gin.Context) {
 	// Kategori parametresini set et
 	params.Category = categoryName
 
-	books, err := h.bookService.GetBooksByCategoryWithPagination(categoryName, params)
+	books, err := h.bookService.GetBooksByCategoryWithPagination(c.Request.Context(), categoryName, params)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_CATEGORY_BOOKS_ERROR", "Kategori kitapları getirilemedi")
 		return
@@ -140,7 +141,7 @@ func (h *BookHandler) GetEnrichedBooks(c *gin.Context) {
 		return
 	}
 
-	enrichedBooks, err := h.bookService.GetEnrichedBooks(params)
+	enrichedBooks, err := h.bookService.GetEnrichedBooks(c.Request.Context(), params)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_ENRICHED_BOOKS_ERROR", "Zenginleştirilmiş kitaplar getirilemedi")
 		return
@@ -184,8 +185,9 @@ func (h *BookHandler) respondSuccess(c *gin.Context, data interface{}) {
 func (h *BookHandler) respondError(c *gin.Context, statusCode int, errorCode, message string) {
 	c.JSON(statusCode, gin.H{
 		"error": gin.H{
-			"code":    errorCode,
-			"message": message,
+			"code":       errorCode,
+			"message":    message,
+			"request_id": requestid.Get(c),
 		},
 	})
-} 
\ No newline at end of file
+}
@@ -1,20 +1,22 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
 
 	"book-service/internal/model"
+	"book-service/internal/requestid"
+
 	_ "github.com/lib/pq"
 )
 
 // BookRepository kitap veri erişim interface'i
 type BookRepository interface {
-	GetPaginatedBooks(params *model.BookSearchParams) (*model.PaginatedBooks, error)
-	GetBookByID(id int) (*model.Book, error)
-	GetBooksByAuthor(authorName string) ([]model.Book, error)
-	GetBooksByCategory(categoryName string) ([]model.Book, error)
+	GetPaginatedBooks(ctx context.Context, params *model.BookSearchParams) (*model.PaginatedBooks, error)
+	GetBookByID(ctx context.Context, id int) (*model.Book, error)
+	GetBooksByAuthor(ctx context.Context, authorName string) ([]model.Book, error)
+	GetBooksByCategory(ctx context.Context, categoryName string) ([]model.Book, error)
 	Close() error
 }
 
@@ -31,7 +33,7 @@ func NewPostgreSQLBookRepository(db *sql.DB) BookRepository {
 }
 
 // GetPaginatedBooks sayfalı kitap listesi getirir
-func (r *PostgreSQLBookRepository) GetPaginatedBooks(params *model.BookSearchParams) (*model.PaginatedBooks, error) {
+func (r *PostgreSQLBookRepository) GetPaginatedBooks(ctx context.Context, params *model.BookSearchParams) (*model.PaginatedBooks, error) {
 	if params.Page < 1 {
 		params.Page = 1
 	}
@@ -77,7 +79,7 @@ func (r *PostgreSQLBookRepository) GetPaginatedBooks(params *model.BookSearchPar
 	// Toplam sayıyı al
 	countQuery := `SELECT COUNT(*) FROM books` + whereClause
 	var total int
-	err := r.db.QueryRow(countQuery, args...).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("toplam kitap sayısı sorgulanamadı: %v", err)
 	}
@@ -98,7 +100,7 @@ func (r *PostgreSQLBookRepository) GetPaginatedBooks(params *model.BookSearchPar
 
 	args = append(args, params.PageSize, offset)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("kitaplar sorgulanamadı: %v", err)
 	}
@@ -118,7 +120,7 @@ func (r *PostgreSQLBookRepository) GetPaginatedBooks(params *model.BookSearchPar
 			&bookDB.ReleasedYear,
 		)
 		if err != nil {
-			log.Printf("Kitap verisi okunamadı: %v", err)
+			requestid.Printf(ctx, "Kitap verisi okunamadı: %v", err)
 			continue
 		}
 		books = append(books, bookDB.ToBook())
@@ -140,7 +142,7 @@ func (r *PostgreSQLBookRepository) GetPaginatedBooks(params *model.BookSearchPar
 }
 
 // GetBookByID ID'ye göre kitap getirir
-func (r *PostgreSQLBookRepository) GetBookByID(id int) (*model.Book, error) {
+func (r *PostgreSQLBookRepository) GetBookByID(ctx context.Context, id int) (*model.Book, error) {
 	query := `SELECT 
 		$1 as id,
 		book_title, 
@@ -155,7 +157,7 @@ func (r *PostgreSQLBookRepository) GetBookByID(id int) (*model.Book, error) {
 	LIMIT 1 OFFSET $2`
 
 	var bookDB model.BookDB
-	err := r.db.QueryRow(query, id, id-1).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, id-1).Scan(
 		&bookDB.ID,
 		&bookDB.Title,
 		&bookDB.Publisher,
@@ -178,7 +180,7 @@ func (r *PostgreSQLBookRepository) GetBookByID(id int) (*model.Book, error) {
 }
 
 // GetBooksByAuthor yazar adına göre kitapları getirir
-func (r *PostgreSQLBookRepository) GetBooksByAuthor(authorName string) ([]model.Book, error) {
+func (r *PostgreSQLBookRepository) GetBooksByAuthor(ctx context.Context, authorName string) ([]model.Book, error) {
 	query := `SELECT 
 		ROW_NUMBER() OVER (ORDER BY book_title) as id,
 		book_title, 
@@ -192,7 +194,7 @@ func (r *PostgreSQLBookRepository) GetBooksByAuthor(authorName string) ([]model.
 	WHERE LOWER(book_author) LIKE LOWER($1)
 	ORDER BY book_title`
 
-	rows, err := r.db.Query(query, "%"+authorName+"%")
+	rows, err := r.db.QueryContext(ctx, query, "%"+authorName+"%")
 	if err != nil {
 		return nil, fmt.Errorf("yazar kitapları sorgulanamadı: %v", err)
 	}
@@ -212,7 +214,7 @@ func (r *PostgreSQLBookRepository) GetBooksByAuthor(authorName string) ([]model.
 			&bookDB.ReleasedYear,
 		)
 		if err != nil {
-			log.Printf("Kitap verisi okunamadı: %v", err)
+			requestid.Printf(ctx, "Kitap verisi okunamadı: %v", err)
 			continue
 		}
 		books = append(books, bookDB.ToBook())
@@ -222,7 +224,7 @@ func (r *PostgreSQLBookRepository) GetBooksByAuthor(authorName string) ([]model.
 }
 
 // GetBooksByCategory kategori adına göre kitapları getirir
-func (r *PostgreSQLBookRepository) GetBooksByCategory(categoryName string) ([]model.Book, error) {
+func (r *PostgreSQLBookRepository) GetBooksByCategory(ctx context.Context, categoryName string) ([]model.Book, error) {
 	query := `SELECT 
 		ROW_NUMBER() OVER (ORDER BY book_title) as id,
 		book_title, 
@@ -236,7 +238,7 @@ func (r *PostgreSQLBookRepository) GetBooksByCategory(categoryName string) ([]mo
 	WHERE LOWER(book_category_name) LIKE LOWER($1)
 	ORDER BY book_title`
 
-	rows, err := r.db.Query(query, "%"+categoryName+"%")
+	rows, err := r.db.QueryContext(ctx, query, "%"+categoryName+"%")
 	if err != nil {
 		return nil, fmt.Errorf("kategori kitapları sorgulanamadı: %v", err)
 	}
@@ -256,7 +258,7 @@ func (r *PostgreSQLBookRepository) GetBooksByCategory(categoryName string) ([]mo
 			&bookDB.ReleasedYear,
 		)
 		if err != nil {
-			log.Printf("Kitap verisi okunamadı: %v", err)
+			requestid.Printf(ctx, "Kitap verisi okunamadı: %v", err)
 			continue
 		}
 		books = append(books, bookDB.ToBook())
@@ -271,4 +273,4 @@ func (r *PostgreSQLBookRepository) Close() error {
 		return r.db.Close()
 	}
 	return nil
-} 
\ No newline at end of file
+}
@@ -1,44 +1,54 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"time"
 
+	"book-service/internal/discovery"
 	"book-service/internal/model"
+	"book-service/internal/requestid"
+	"book-service/internal/tracing"
 )
 
 // AuthorService author microservice ile iletişim interface'i
 type AuthorService interface {
-	GetAuthorInfo(authorName string) (*model.AuthorInfo, error)
+	GetAuthorInfo(ctx context.Context, authorName string) (*model.AuthorInfo, error)
 }
 
 // HTTPAuthorService HTTP üzerinden author service implementasyonu
 type HTTPAuthorService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-// NewHTTPAuthorService yeni HTTP author service oluşturur
-func NewHTTPAuthorService(baseURL string) AuthorService {
+// NewHTTPAuthorService yeni HTTP author service oluşturur; her istek endpoint'in çözdüğü instance'a gider
+func NewHTTPAuthorService(endpoint *discovery.Endpoint) AuthorService {
 	return &HTTPAuthorService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
 // GetAuthorInfo author service'den yazar bilgisini getirir
-func (s *HTTPAuthorService) GetAuthorInfo(authorName string) (*model.AuthorInfo, error) {
-	url := fmt.Sprintf("%s/api/authors/search?name=%s", s.baseURL, authorName)
-	
-	log.Printf("Author service'e istek gönderiliyor: %s", url)
-	
-	resp, err := s.httpClient.Get(url)
+func (s *HTTPAuthorService) GetAuthorInfo(ctx context.Context, authorName string) (*model.AuthorInfo, error) {
+	url := fmt.Sprintf("%s/api/authors/search?name=%s", s.endpoint.URL(), authorName)
+
+	requestid.Printf(ctx, "Author service'e istek gönderiliyor: %s", url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, model.NewBookError("AUTHOR_SERVICE_ERROR", "Author service isteği oluşturulamadı", err)
+	}
+	requestid.Inject(ctx, req)
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, model.NewBookError("AUTHOR_SERVICE_ERROR", "Author service'e bağlanamadı", err)
 	}
@@ -56,7 +66,7 @@ func (s *HTTPAuthorService) GetAuthorInfo(authorName string) (*model.AuthorInfo,
 	var authorResponse struct {
 		Data []map[string]interface{} `json:"data"`
 	}
-	
+
 	if err := json.Unmarshal(body, &authorResponse); err != nil {
 		return nil, model.NewBookError("AUTHOR_SERVICE_PARSE_ERROR", "Author service yanıtı parse edilemedi", err)
 	}
@@ -70,9 +80,9 @@ func (s *HTTPAuthorService) GetAuthorInfo(authorName string) (*model.AuthorInfo,
 
 	// İlk yazarı al
 	author := authorResponse.Data[0]
-	
+
 	return &model.AuthorInfo{
 		Name:      authorName,
 		Biography: fmt.Sprintf("Yazar hakkında bilgi: %v", author),
 	}, nil
-} 
\ No newline at end of file
+}
@@ -1,22 +1,23 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"log"
 
 	"book-service/internal/model"
 	"book-service/internal/repository"
+	"book-service/internal/requestid"
 )
 
 // BookService kitap iş mantığı interface'i
 type BookService interface {
-	GetPaginatedBooks(params *model.BookSearchParams) (*model.PaginatedBooks, error)
-	GetBookByID(id int) (*model.Book, error)
-	GetEnrichedBookByID(id int) (*model.EnrichedBook, error)
-	GetBooksByAuthor(authorName string) ([]model.Book, error)
-	GetBooksByCategory(categoryName string) ([]model.Book, error)
-	GetBooksByCategoryWithPagination(categoryName string, params *model.BookSearchParams) (*model.PaginatedBooks, error)
-	GetEnrichedBooks(params *model.BookSearchParams) ([]*model.EnrichedBook, error)
+	GetPaginatedBooks(ctx context.Context, params *model.BookSearchParams) (*model.PaginatedBooks, error)
+	GetBookByID(ctx context.Context, id int) (*model.Book, error)
+	GetEnrichedBookByID(ctx context.Context, id int) (*model.EnrichedBook, error)
+	GetBooksByAuthor(ctx context.Context, authorName string) ([]model.Book, error)
+	GetBooksByCategory(ctx context.Context, categoryName string) ([]model.Book, error)
+	GetBooksByCategoryWithPagination(ctx context.Context, categoryName string, params *model.BookSearchParams) (*model.PaginatedBooks, error)
+	GetEnrichedBooks(ctx context.Context, params *model.BookSearchParams) ([]*model.EnrichedBook, error)
 }
 
 // BookServiceImpl BookService implementasyonu
@@ -34,36 +35,36 @@ func NewBookService(bookRepo repository.BookRepository, authorService AuthorServ
 }
 
 // GetPaginatedBooks sayfalı kitap listesi getirir
-func (s *BookServiceImpl) GetPaginatedBooks(params *model.BookSearchParams) (*model.PaginatedBooks, error) {
+func (s *BookServiceImpl) GetPaginatedBooks(ctx context.Context, params *model.BookSearchParams) (*model.PaginatedBooks, error) {
 	// Parametreleri doğrula
 	if err := s.validateSearchParams(params); err != nil {
 		return nil, err
 	}
 
-	return s.bookRepo.GetPaginatedBooks(params)
+	return s.bookRepo.GetPaginatedBooks(ctx, params)
 }
 
 // GetBookByID ID'ye göre kitap getirir
-func (s *BookServiceImpl) GetBookByID(id int) (*model.Book, error) {
+func (s *BookServiceImpl) GetBookByID(ctx context.Context, id int) (*model.Book, error) {
 	if id <= 0 {
 		return nil, model.ErrInvalidBookID
 	}
 
-	return s.bookRepo.GetBookByID(id)
+	return s.bookRepo.GetBookByID(ctx, id)
 }
 
 // GetEnrichedBookByID ID'ye göre yazar bilgisiyle zenginleştirilmiş kitap getirir
-func (s *BookServiceImpl) GetEnrichedBookByID(id int) (*model.EnrichedBook, error) {
+func (s *BookServiceImpl) GetEnrichedBookByID(ctx context.Context, id int) (*model.EnrichedBook, error) {
 	// Önce kitap bilgisini al
-	book, err := s.GetBookByID(id)
+	book, err := s.GetBookByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Yazar bilgisini al
-	authorInfo, err := s.authorService.GetAuthorInfo(book.Author)
+	authorInfo, err := s.authorService.GetAuthorInfo(ctx, book.Author)
 	if err != nil {
-		log.Printf("Yazar bilgisi alınamadı: %v", err)
+		requestid.Printf(ctx, "Yazar bilgisi alınamadı: %v", err)
 		// Hata olsa bile kitap bilgisini döndür
 		authorInfo = &model.AuthorInfo{
 			Name:      book.Author,
@@ -75,25 +76,25 @@ func (s *BookServiceImpl) GetEnrichedBookByID(id int) (*model.EnrichedBook, erro
 }
 
 // GetBooksByAuthor yazar adına göre kitapları getirir
-func (s *BookServiceImpl) GetBooksByAuthor(authorName string) ([]model.Book, error) {
+func (s *BookServiceImpl) GetBooksByAuthor(ctx context.Context, authorName string) ([]model.Book, error) {
 	if authorName == "" {
 		return nil, model.ErrInvalidAuthor
 	}
 
-	return s.bookRepo.GetBooksByAuthor(authorName)
+	return s.bookRepo.GetBooksByAuthor(ctx, authorName)
 }
 
 // GetBooksByCategory kategori adına göre kitapları getirir
-func (s *BookServiceImpl) GetBooksByCategory(categoryName string) ([]model.Book, error) {
+func (s *BookServiceImpl) GetBooksByCategory(ctx context.Context, categoryName string) ([]model.Book, error) {
 	if categoryName == "" {
 		return nil, errors.New("kategori adı boş olamaz")
 	}
 
-	return s.bookRepo.GetBooksByCategory(categoryName)
+	return s.bookRepo.GetBooksByCategory(ctx, categoryName)
 }
 
 // GetBooksByCategoryWithPagination kategori adına göre sayfalanmış kitapları getirir
-func (s *BookServiceImpl) GetBooksByCategoryWithPagination(categoryName string, params *model.BookSearchParams) (*model.PaginatedBooks, error) {
+func (s *BookServiceImpl) GetBooksByCategoryWithPagination(ctx context.Context, categoryName string, params *model.BookSearchParams) (*model.PaginatedBooks, error) {
 	if categoryName == "" {
 		return nil, errors.New("kategori adı boş olamaz")
 	}
@@ -106,11 +107,11 @@ func (s *BookServiceImpl) GetBooksByCategoryWithPagination(categoryName string,
 	// Kategori filtresini ayarla
 	params.Category = categoryName
 
-	return s.bookRepo.GetPaginatedBooks(params)
+	return s.bookRepo.GetPaginatedBooks(ctx, params)
 }
 
 // GetEnrichedBooks yazar bilgisiyle zenginleştirilmiş kitap listesi getirir
-func (s *BookServiceImpl) GetEnrichedBooks(params *model.BookSearchParams) ([]*model.EnrichedBook, error) {
+func (s *BookServiceImpl) GetEnrichedBooks(ctx context.Context, params *model.BookSearchParams) ([]*model.EnrichedBook, error) {
 	// Parametreleri doğrula
 	if err := s.validateSearchParams(params); err != nil {
 		return nil, err
@@ -121,7 +122,7 @@ func (s *BookServiceImpl) GetEnrichedBooks(params *model.BookSearchParams) ([]*m
 		params.PageSize = 10
 	}
 
-	result, err := s.bookRepo.GetPaginatedBooks(params)
+	result, err := s.bookRepo.GetPaginatedBooks(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -129,9 +130,9 @@ func (s *BookServiceImpl) GetEnrichedBooks(params *model.BookSearchParams) ([]*m
 	// Her kitap için yazar bilgisini zenginleştir
 	enrichedBooks := make([]*model.EnrichedBook, len(result.Books))
 	for i, book := range result.Books {
-		authorInfo, err := s.authorService.GetAuthorInfo(book.Author)
+		authorInfo, err := s.authorService.GetAuthorInfo(ctx, book.Author)
 		if err != nil {
-			log.Printf("Yazar bilgisi alınamadı (%s): %v", book.Author, err)
+			requestid.Printf(ctx, "Yazar bilgisi alınamadı (%s): %v", book.Author, err)
 			authorInfo = &model.AuthorInfo{
 				Name:      book.Author,
 				Biography: "Yazar bilgisi şu anda mevcut değil",
@@ -149,10 +150,10 @@ func (s *BookServiceImpl) validateSearchParams(params *model.BookSearchParams) e
 	if params.Page < 1 {
 		return model.ErrInvalidPage
 	}
-	
+
 	if params.PageSize < 1 || params.PageSize > 100 {
 		return model.ErrInvalidPageSize
 	}
 
 	return nil
-} 
\ No newline at end of file
+}
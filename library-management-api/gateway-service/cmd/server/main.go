@@ -1,11 +1,23 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	"gateway-service/configs"
+	"gateway-service/internal/graph"
 	"gateway-service/internal/handler"
+	"gateway-service/internal/logging"
+	"gateway-service/internal/metrics"
+	"gateway-service/internal/middleware"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/server"
 	"gateway-service/internal/service"
+	"gateway-service/internal/tracing"
+	"gateway-service/utils"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -13,82 +25,323 @@ import (
 
 func main() {
 	// Konfigürasyonu yükle
-	cfg := configs.LoadConfig()
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatal("Konfigürasyon yüklenemedi:", err)
+	}
+	logLevel, _ := logging.ParseLevel(cfg.Log.Level)
+	logging.SetLevel(logLevel)
+
+	// Kontrollü kapanma - SIGTERM'de health check DRAINING döner ve devam eden istekler beklenir
+	srv := server.New(cfg.Shutdown)
+
+	// Dağıtık tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "gateway-service", cfg.Tracing)
+	if err != nil {
+		log.Fatal("Tracing başlatılamadı:", err)
+	}
+	srv.OnShutdown("Tracing", shutdownTracing)
 
 	// Dependency Injection - katmanlarını oluştur
-	proxyService := service.NewProxyService()
-	gatewayHandler := handler.NewGatewayHandler(proxyService, cfg)
+	gatewayMetrics := metrics.New("gateway-service")
+	loadBalancer := service.NewLoadBalancer(cfg.Services)
+	circuitBreakers := service.NewCircuitBreakerRegistry(cfg.CircuitBreaker)
+	retryPolicy := service.NewRetryPolicy(cfg.Retry)
+	proxyService := service.NewProxyService(loadBalancer, circuitBreakers, retryPolicy, metrics.NewUpstreamMetrics(gatewayMetrics), cfg.Server.TrustedProxies, cfg.Proxy)
+	routeService := service.NewRouteService(cfg)
+	responseCache := service.NewLRUResponseCache(cfg.Cache.MaxBytes)
+	healthChecker := service.NewHealthChecker(cfg.HealthCheck, loadBalancer)
+	serviceRegistry := service.NewMemoryServiceRegistry(cfg.Registry, cfg.Services, loadBalancer)
+	registryHandler := handler.NewRegistryHandler(serviceRegistry, loadBalancer)
+	compositeService := service.NewCompositeService(proxyService, cfg.Composite)
+	trafficSplitter := service.NewTrafficSplitter(metrics.NewSplitMetrics(gatewayMetrics))
+	trafficMirror := service.NewTrafficMirror(proxyService, metrics.NewMirrorMetrics(gatewayMetrics), cfg.Mirror, cfg.Proxy)
+	srv.OnShutdown("Shadow karşılaştırma log dosyası", func(context.Context) error { return trafficMirror.Close() })
+	gatewayHandler := handler.NewGatewayHandler(proxyService, routeService, healthChecker, compositeService, trafficSplitter, trafficMirror, cfg)
+	adminHandler := handler.NewAdminHandler(routeService, loadBalancer, circuitBreakers, responseCache, healthChecker, service.NewMemoryAuditLog(cfg.Admin.AuditEntries), cfg)
+	graphExecutor, err := graph.NewExecutor(proxyService, cfg.GraphQL)
+	if err != nil {
+		log.Fatal("GraphQL şeması oluşturulamadı:", err)
+	}
+	graphqlHandler := handler.NewGraphQLHandler(graphExecutor)
+	authMiddleware := middleware.NewAuthMiddleware(utils.NewJWTVerifier(cfg.JWT.SecretKey), routeService)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(service.NewMemoryRateLimitStore(), routeService, cfg.RateLimitStore)
+	cacheMiddleware := middleware.NewCacheMiddleware(responseCache, routeService, cfg.Cache)
+	compressionMiddleware := middleware.NewCompressionMiddleware(cfg.Compression)
+
+	// Arka plan işleri kapanmada durdurulur
+	workers, stopWorkers := context.WithCancel(context.Background())
+	srv.OnShutdown("Arka plan işleri (route izleme, health check, registry)", func(context.Context) error {
+		stopWorkers()
+		return nil
+	})
+
+	// Route dosyasındaki değişiklikleri izle
+	go routeService.Watch(workers)
+
+	// Upstream instance'larını arka planda aktif olarak kontrol et
+	go healthChecker.Start(workers)
+
+	// Heartbeat göndermeyen registry kayıtlarını temizle
+	if cfg.Registry.Token != "" {
+		go serviceRegistry.Start(workers)
+	}
 
 	// Gin router'ını oluştur
-	r := gin.Default()
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Geçersiz TRUSTED_PROXIES:", err)
+	}
+
+	// İstek ID'si - access log ve tüm gateway log'ları bu ID ile ilişkilendirilir
+	r.Use(requestid.Middleware())
+	r.Use(accessLogger(), gin.Recovery())
+
+	// Prometheus metrikleri - dinamik route'lar route prefix'i ile etiketlenir
+	if cfg.Metrics.Enabled {
+		gatewayMetrics.Register(service.NewUpstreamStateCollector(loadBalancer, circuitBreakers))
+		r.Use(gatewayMetrics.Middleware(routeLabel(routeService)))
+		r.GET(cfg.Metrics.Path, gin.WrapH(gatewayMetrics.Handler()))
+	}
+
+	// Dağıtık tracing - metrics endpoint'i span üretmez, upstream'lere traceparent aktarılır
+	r.Use(tracing.Middleware(routeLabel(routeService)))
 
-	// CORS ayarları
-	setupCORS(r)
+	// CORS politikası - preflight istekleri authentication ve rate limit'ten önce yanıtlanır
+	setupCORS(r, cfg.CORS)
+
+	// Edge authentication - kullanıcı bazlı rate limit kimliğe ihtiyaç duyduğu için önce çalışır
+	r.Use(authMiddleware.Authenticate())
+
+	// Rate limiting
+	r.Use(rateLimitMiddleware.Limit())
+
+	// GET yanıt cache'i
+	if cfg.Cache.Enabled {
+		r.Use(cacheMiddleware.Cache())
+	}
+
+	// Yanıt sıkıştırma (br/gzip) ve sıkıştırılmış istek body'lerinin açılması -
+	// cache'ten sonra çalışır, cache kodlamaya göre ayrı varyant saklar
+	if cfg.Compression.Enabled {
+		r.Use(compressionMiddleware.Compress())
+	}
 
 	// Route'ları ayarla
-	setupRoutes(r, gatewayHandler)
+	setupRoutes(r, gatewayHandler, graphqlHandler, registryHandler, authMiddleware, rateLimitMiddleware, srv, cfg)
 
-	// Servisi başlat
-	startServer(r, cfg)
+	// Servisi ve ayrı portta çalışan yönetim API'sini başlat
+	startServer(srv, r, newAdminServer(adminHandler, cfg), cfg, routeService)
 }
 
-func setupCORS(r *gin.Engine) {
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+// stickyLabel traffic split sticky modunu okunabilir hale getirir
+func stickyLabel(sticky string) string {
+	if sticky == configs.StickyNone {
+		return "yok"
+	}
+	return sticky
+}
+
+// routeLabel metrik etiketi ve span adı olarak gin route şablonunu, yoksa eşleşen gateway route prefix'ini
+// (versiyonlu isteklerde /api/v1/books gibi) kullanır
+func routeLabel(routeService service.RouteService) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		if path := c.FullPath(); path != "" {
+			return path
+		}
+		if route, ok := routeService.Match(c.Request.URL.Path); ok {
+			return route.RequestPrefix()
+		}
+		return ""
+	}
+}
+
+// accessLogger istek ID'li access log'u aktif log seviyesine göre yazar
+func accessLogger() gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Formatter: requestid.LogFormatter,
+		Skip:      requestid.SkipLog,
+	})
+}
+
+// setupCORS CORS_* ile tanımlanan politikayı uygular; izinli olmayan origin'lerden gelen istekler 403 alır
+func setupCORS(r *gin.Engine, policy configs.CORSConfig) {
+	config := cors.Config{
+		AllowMethods:     policy.AllowedMethods,
+		AllowHeaders:     policy.AllowedHeaders,
+		ExposeHeaders:    policy.ExposedHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           policy.MaxAge,
+	}
+	if policy.AllowsAllOrigins() {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOriginFunc = policy.AllowsOrigin
+	}
 	r.Use(cors.New(config))
 }
 
-func setupRoutes(r *gin.Engine, h *handler.GatewayHandler) {
-	// Gateway health check
-	r.GET("/health", h.HealthCheck)
+func setupRoutes(r *gin.Engine, h *handler.GatewayHandler, gql *handler.GraphQLHandler, registry *handler.RegistryHandler, auth *middleware.AuthMiddleware, rateLimit *middleware.RateLimitMiddleware, srv *server.Server, cfg *configs.Config) {
+	// Gateway health check - kapanma sırasında DRAINING döner
+	r.GET("/health", srv.Readiness(), h.HealthCheck)
+
+	// Servisler üzerinde GraphQL - resolver'lar REST servislerini dataloader ile çağırır.
+	// Route tablosunda olmadığı için token ve rate limit burada zorunlu kılınır.
+	if cfg.GraphQL.Enabled {
+		graphql := r.Group(cfg.GraphQL.Path, auth.RequireIdentity(), rateLimit.LimitGroup(cfg.GraphQL.RateLimit))
+		{
+			graphql.GET("", gql.Serve)
+			graphql.POST("", gql.Serve)
+		}
+	}
 
 	// API grubu
 	api := r.Group("/api")
 	{
-		// Dinamik service routing - herhangi bir path'i ilgili servise yönlendir
-		api.Any("/books/*path", h.RouteToService)
-		api.Any("/books", h.RouteToService)
-		
-		api.Any("/authors/*path", h.RouteToService)
-		api.Any("/authors", h.RouteToService)
-		
-		api.Any("/genres/*path", h.RouteToService)
-		api.Any("/genres", h.RouteToService)
-		
-		api.Any("/recommendations/*path", h.RouteToService)
-		api.Any("/recommendations", h.RouteToService)
-		
-		api.Any("/auth/*path", h.RouteToService)
-		api.Any("/auth", h.RouteToService)
-		
 		// Services health check
 		api.GET("/health", h.ServicesHealthCheck)
+
+		// Birden fazla servisten birleştirilen endpoint'ler - auth gerektiren route'ların verisini döndüğü için token zorunludur
+		composite := api.Group("/composite", auth.RequireIdentity(), rateLimit.LimitGroup(cfg.Composite.RateLimit))
+		{
+			composite.GET("/books/:id", h.CompositeBookDetail)
+		}
 	}
+
+	// Service registry - servisler açılışta kaydolur, heartbeat gönderir ve kapanırken kaydını siler;
+	// servisler arası istemciler diğer servislerin instance'larını buradan bulur
+	if cfg.Registry.Token != "" {
+		reg := r.Group("/registry", middleware.RequireRegistryToken(cfg.Registry.Token))
+		{
+			reg.POST("/instances", registry.Register)
+			reg.PUT("/instances/:id/heartbeat", registry.Heartbeat)
+			reg.DELETE("/instances/:id", registry.Deregister)
+			reg.GET("/services", registry.Services)
+			reg.GET("/services/:name", registry.Service)
+		}
+	}
+
+	// Dinamik service routing - route tablosundaki prefix'lere göre ilgili servise yönlendir
+	r.NoRoute(h.RouteToService)
 }
 
-func startServer(r *gin.Engine, cfg *configs.Config) {
+// newAdminServer route, upstream, cache ve log seviyesi yönetimini ayrı porttan sunan server'ı oluşturur.
+// ADMIN_TOKEN veya ADMIN_PORT tanımlı değilse yönetim API'si başlatılmaz (nil döner).
+func newAdminServer(admin *handler.AdminHandler, cfg *configs.Config) *http.Server {
+	if cfg.Admin.Token == "" || cfg.Admin.Port == "" {
+		log.Println("⚠️ Yönetim API'si devre dışı (ADMIN_TOKEN ve ADMIN_PORT tanımlanmalı)")
+		return nil
+	}
+	if !cfg.Admin.Loopback() {
+		log.Printf("⚠️ Yönetim API'si loopback dışı bir adreste (%s) dinleyecek; ADMIN_HOST sadece güvenilir ağlardan erişilebilir olmalı", cfg.GetAdminAddress())
+	}
+
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.Use(accessLogger(), gin.Recovery())
+
+	group := r.Group("/admin", middleware.RequireAdminToken(cfg.Admin.Token))
+	{
+		group.GET("/routes", admin.Routes)
+		group.POST("/routes/reload", admin.ReloadRoutes)
+		group.GET("/upstreams", admin.Upstreams)
+		group.PATCH("/upstreams/:upstream/instances", admin.UpdateInstance)
+		group.GET("/cache", admin.CacheStats)
+		group.DELETE("/cache", admin.PurgeCache)
+		group.GET("/log-level", admin.LogLevel)
+		group.PUT("/log-level", admin.SetLogLevel)
+		group.GET("/audit", admin.AuditLog)
+	}
+
+	return &http.Server{
+		Addr:           cfg.GetAdminAddress(),
+		Handler:        r,
+		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+	}
+}
+
+func startServer(srv *server.Server, r *gin.Engine, adminServer *http.Server, cfg *configs.Config, routeService service.RouteService) {
 	serverAddr := cfg.GetServerAddress()
 	log.Printf("Gateway service %s adresinde başlatılıyor...", serverAddr)
-	
-	printAPIInfo()
-	
-	if err := r.Run(serverAddr); err != nil {
+
+	printAPIInfo(cfg, routeService)
+
+	servers := []*http.Server{{
+		Addr:           serverAddr,
+		Handler:        r,
+		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+	}}
+	if adminServer != nil {
+		log.Printf("🛠️ Yönetim API'si %s adresinde başlatılıyor...", adminServer.Addr)
+		servers = append(servers, adminServer)
+	}
+	if err := srv.Run(servers...); err != nil {
 		log.Fatal("Server başlatılamadı:", err)
 	}
 }
 
-func printAPIInfo() {
+func printAPIInfo(cfg *configs.Config, routeService service.RouteService) {
 	log.Println("🚀 Microservices Gateway Başlatıldı")
-	log.Println("📡 Dinamik Routing Aktif:")
-	log.Println("  📚 /api/books/*        -> Book Service")
-	log.Println("  ✍️  /api/authors/*      -> Author Service")
-	log.Println("  📖 /api/genres/*        -> Genre Service")
-	log.Println("  🤖 /api/recommendations/* -> Recommendation Service")
-	log.Println("  🔐 /api/auth/*          -> Auth Service")
-	log.Println("  🩺 /api/health          -> Services Health Check")
+	if cfg.Routing.File != "" {
+		log.Printf("📡 Dinamik Routing Aktif (%s, her %s kontrol ediliyor):", cfg.Routing.File, cfg.Routing.ReloadInterval)
+	} else {
+		log.Println("📡 Dinamik Routing Aktif (varsayılan route'lar):")
+	}
+	for _, route := range routeService.Routes() {
+		methods := "ANY"
+		if len(route.Methods) > 0 {
+			methods = strings.Join(route.Methods, ",")
+		}
+		prefix := route.Prefix + "/*"
+		if route.Version != "" {
+			prefix += " (" + route.Version + ")"
+		}
+		limits := route.Limits(cfg.Proxy)
+		log.Printf("  🔀 %-24s -> %s [%s, %s, auth=%s, timeout=%s, body≤%d]", prefix, route.Upstream, methods, route.LoadBalancer.Strategy, route.AuthPolicy(), limits.Timeout, limits.MaxBodyBytes)
+		if split := route.Split; split != nil {
+			versions := make([]string, 0, len(split.Versions))
+			for _, version := range split.Versions {
+				versions = append(versions, fmt.Sprintf("%s=%s(%d)", version.Name, version.Upstream, version.Weight))
+			}
+			log.Printf("    🐤 Trafik bölme: %s (sticky: %s)", strings.Join(versions, ", "), stickyLabel(split.Sticky))
+		}
+		if mirror := route.Mirror; mirror != nil {
+			log.Printf("    🪞 Shadow trafik: GET isteklerinin %%%.1f'i -> %s", mirror.Percentage, mirror.Upstream)
+		}
+	}
+	if versioning := cfg.Routing.Versioning; versioning != nil {
+		log.Printf("  🏷️ API versiyonları: %s/{versiyon}/... (versiyonsuz istekler -> %s)", versioning.Prefix, versioning.Default)
+		for name, version := range versioning.Versions {
+			switch {
+			case version.Deprecated() && version.Sunset != "":
+				log.Printf("    ⚠️ %s deprecated, sunset: %s", name, version.Sunset)
+			case version.Deprecated():
+				log.Printf("    ⚠️ %s deprecated", name)
+			}
+		}
+	}
+	log.Printf("  🩺 /api/health          -> Services Health Check (her %s güncellenir, instance detayları /admin/upstreams altında)", cfg.HealthCheck.Interval)
 	log.Println("  🩺 /health              -> Gateway Health Check")
+	log.Printf("  🧩 /api/composite/books/:id -> Kitap + yazar + tür + öneriler (auth=required, rate limit: %s, timeout %s)", cfg.Composite.RateLimit, cfg.Composite.Timeout)
+	if cfg.GraphQL.Enabled {
+		log.Printf("  🕸️ %-20s -> GraphQL (auth=required, rate limit: %s, derinlik ≤ %d, karmaşıklık ≤ %d)", cfg.GraphQL.Path, cfg.GraphQL.RateLimit, cfg.GraphQL.MaxDepth, cfg.GraphQL.MaxComplexity)
+	}
+	if cfg.Metrics.Enabled {
+		log.Printf("  📈 %-20s -> Prometheus metrikleri", cfg.Metrics.Path)
+	}
+	if cfg.Compression.Enabled {
+		log.Printf("  🗜️ Sıkıştırma: br/gzip (≥ %d byte, seviye %d)", cfg.Compression.MinBytes, cfg.Compression.Level)
+	}
+	log.Printf("  🌍 CORS: %s (credentials: %t, preflight cache %s)", strings.Join(cfg.CORS.AllowedOrigins, ", "), cfg.CORS.AllowCredentials, cfg.CORS.MaxAge)
+	log.Printf("  ⏱️ Upstream timeout varsayılanları: connect %s, yanıt header %s, toplam %s", cfg.Proxy.ConnectTimeout, cfg.Proxy.ResponseHeaderTimeout, cfg.Proxy.Timeout)
+	if cfg.Admin.Token != "" && cfg.Admin.Port != "" {
+		log.Printf("  🛠️ Yönetim API'si: http://%s/admin (X-Admin-Token, log seviyesi: %s)", cfg.GetAdminAddress(), logging.CurrentLevel())
+	}
+	if cfg.Registry.Token != "" {
+		log.Printf("  📇 /registry            -> Service registry (X-Registry-Token, TTL %s)", cfg.Registry.TTL)
+	}
+	log.Printf("  🛑 Kontrollü kapanma: istekler için en fazla %s (drain bekleme %s)", cfg.Shutdown.Timeout, cfg.Shutdown.DrainDelay)
+	log.Printf("  🔭 Tracing exporter: %s (sample ratio: %.2f)", cfg.Tracing.Exporter, cfg.Tracing.SampleRatio)
 	log.Println("")
 	log.Println("🔗 Gateway URL: http://localhost:3000")
-} 
\ No newline at end of file
+}
@@ -2,45 +2,540 @@ package configs
 
 import (
 	"fmt"
+	"log"
+	"net"
 	"os"
+	"strings"
+	"time"
+
+	"gateway-service/internal/logging"
 )
 
 // Config uygulama konfigürasyonu
 type Config struct {
 	Server   ServerConfig   `json:"server"`
 	Services ServicesConfig `json:"services"`
+	Routing  RoutingConfig  `json:"routing"`
+	Proxy    ProxyConfig    `json:"proxy"`
+
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker"`
+	Retry          RetryConfig          `json:"retry"`
+	JWT            JWTConfig            `json:"jwt"`
+	Cache          CacheConfig          `json:"cache"`
+	Admin          AdminConfig          `json:"admin"`
+	HealthCheck    HealthCheckConfig    `json:"health_check"`
+	Metrics        MetricsConfig        `json:"metrics"`
+	Tracing        TracingConfig        `json:"tracing"`
+	Composite      CompositeConfig      `json:"composite"`
+	GraphQL        GraphQLConfig        `json:"graphql"`
+	Compression    CompressionConfig    `json:"compression"`
+	Log            LogConfig            `json:"log"`
+	Shutdown       ShutdownConfig       `json:"shutdown"`
+	Registry       RegistryConfig       `json:"registry"`
+	Mirror         MirrorConfig         `json:"mirror"`
+	CORS           CORSConfig           `json:"cors"`
+	RateLimitStore RateLimitStoreConfig `json:"rate_limit_store"`
 }
 
 // ServerConfig server konfigürasyonu
 type ServerConfig struct {
-	Port string `json:"port"`
-	Host string `json:"host"`
+	Port           string   `json:"port"`
+	Host           string   `json:"host"`
+	TrustedProxies []string `json:"trusted_proxies"`
+	MaxHeaderBytes int      `json:"max_header_bytes"` // istek satırı + header'lar için üst sınır; aşılırsa 431
 }
 
-// ServicesConfig mikroservis URL'leri
+// ProxyConfig upstream istekleri için varsayılan timeout'lar ve istek body sınırı; route'lar kendi değerleriyle ezebilir
+type ProxyConfig struct {
+	ConnectTimeout        time.Duration `json:"connect_timeout"`         // TCP bağlantısının kurulması
+	ResponseHeaderTimeout time.Duration `json:"response_header_timeout"` // istek gönderildikten sonra yanıt header'larının gelmesi
+	Timeout               time.Duration `json:"timeout"`                 // retry'lar dahil isteğin toplam süresi
+	IdleTimeout           time.Duration `json:"idle_timeout"`            // SSE ve WebSocket akışlarında veri aktarılmadan geçebilecek süre
+	MaxBodyBytes          int64         `json:"max_body_bytes"`          // aşılırsa 413
+}
+
+// ServicesConfig mikroservis URL'leri - her servis virgülle ayrılmış birden fazla instance alabilir
 type ServicesConfig struct {
-	BookServiceURL          string `json:"book_service_url"`
-	AuthorServiceURL        string `json:"author_service_url"`
-	GenreServiceURL         string `json:"genre_service_url"`
-	RecommendationServiceURL string `json:"recommendation_service_url"`
-	AuthServiceURL          string `json:"auth_service_url"`
+	BookServiceURLs           []string `json:"book_service_urls"`
+	AuthorServiceURLs         []string `json:"author_service_urls"`
+	GenreServiceURLs          []string `json:"genre_service_urls"`
+	RecommendationServiceURLs []string `json:"recommendation_service_urls"`
+	AuthServiceURLs           []string `json:"auth_service_urls"`
+
+	// Extra route'ların ek upstream olarak kullanabileceği havuzlar (ör. canary sürümleri): ad -> instance URL'leri
+	Extra map[string][]string `json:"extra"`
+}
+
+// JWTConfig gateway'de token doğrulama konfigürasyonu (auth-service ile aynı secret kullanılmalı)
+type JWTConfig struct {
+	SecretKey string `json:"secret_key"`
+}
+
+// CacheConfig gateway response cache konfigürasyonu
+type CacheConfig struct {
+	Enabled       bool  `json:"enabled"`
+	MaxBytes      int64 `json:"max_bytes"`
+	MaxEntryBytes int64 `json:"max_entry_bytes"`
+}
+
+// AdminConfig gateway yönetim endpoint'leri ve ayrı porttan sunulan yönetim API'si konfigürasyonu
+type AdminConfig struct {
+	Token        string `json:"token"`
+	Host         string `json:"host"`          // varsayılan 127.0.0.1; yönetim API'si sadece yerel makineden erişilebilir
+	Port         string `json:"port"`          // boşsa yönetim API'si başlatılmaz
+	AuditEntries int    `json:"audit_entries"` // bellekte tutulan son değişiklik kaydı sayısı
+}
+
+// RateLimitStoreConfig rate limit store'unun davranışı; limit grupları route dosyasında tanımlanır
+type RateLimitStoreConfig struct {
+	FailOpen bool `json:"fail_open"` // store hata verdiğinde istek geçer (true) veya 503 alır (false)
+}
+
+// MirrorConfig route'larda tanımlanan shadow trafik kopyalamanın ortak ayarları
+type MirrorConfig struct {
+	LogFile       string `json:"log_file"`       // karşılaştırma kayıtlarının JSON satırları olarak yazıldığı dosya; boşsa sadece log'a yazılır
+	MaxConcurrent int    `json:"max_concurrent"` // aynı anda bekleyen en fazla shadow isteği; aşılırsa istek kopyalanmaz
+	MaxBodyBytes  int64  `json:"max_body_bytes"` // body karşılaştırması için saklanan en fazla yanıt boyutu
+	MaxDiffs      int    `json:"max_diffs"`      // kayıt başına yazılan en fazla body farkı
+}
+
+// RegistryConfig gateway'e gömülü service registry konfigürasyonu.
+// Servisler /registry altından kaydolur ve heartbeat gönderir; TTL içinde heartbeat göndermeyen instance'lar silinir.
+type RegistryConfig struct {
+	Token string        `json:"token"` // X-Registry-Token; boşsa registry kapalıdır ve sadece statik URL'ler kullanılır
+	TTL   time.Duration `json:"ttl"`
+}
+
+// ShutdownConfig kontrollü kapanma konfigürasyonu
+type ShutdownConfig struct {
+	Timeout    time.Duration `json:"timeout"`     // devam eden istekler ve kaynakların kapatılması için beklenecek en uzun süre
+	DrainDelay time.Duration `json:"drain_delay"` // health check DRAINING döndükten sonra bağlantıları kesmeden önce beklenen süre
+}
+
+// LogConfig gateway log konfigürasyonu
+type LogConfig struct {
+	Level string `json:"level"` // debug, info, warn, error; yönetim API'sinden çalışma anında değiştirilebilir
+}
+
+// CircuitBreakerConfig upstream circuit breaker konfigürasyonu
+type CircuitBreakerConfig struct {
+	Enabled          bool          `json:"enabled"`
+	FailureRatio     float64       `json:"failure_ratio"`
+	MinRequests      int           `json:"min_requests"`
+	Window           time.Duration `json:"window"`
+	CoolDown         time.Duration `json:"cool_down"`
+	HalfOpenRequests int           `json:"half_open_requests"`
+}
+
+// RetryConfig idempotent istekler için retry konfigürasyonu
+type RetryConfig struct {
+	Enabled      bool          `json:"enabled"`
+	MaxRetries   int           `json:"max_retries"`
+	BaseDelay    time.Duration `json:"base_delay"`
+	MaxDelay     time.Duration `json:"max_delay"`
+	StatusCodes  []int         `json:"status_codes"`
+	BudgetRatio  float64       `json:"budget_ratio"`
+	BudgetBurst  int           `json:"budget_burst"`
+	MaxBodyBytes int64         `json:"max_body_bytes"`
+}
+
+// MetricsConfig Prometheus metrics konfigürasyonu
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+}
+
+// TracingConfig dağıtık tracing konfigürasyonu
+type TracingConfig struct {
+	Exporter     string  `json:"exporter"`      // none, stdout, file veya otlp
+	FilePath     string  `json:"file_path"`     // file exporter'ın span'leri yazdığı dosya
+	OTLPEndpoint string  `json:"otlp_endpoint"` // boşsa OTEL_EXPORTER_OTLP_ENDPOINT veya localhost:4318
+	SampleRatio  float64 `json:"sample_ratio"`
+}
+
+// CompositeConfig gateway'de birden fazla servisten birleştirilen endpoint'lerin konfigürasyonu
+type CompositeConfig struct {
+	Timeout             time.Duration `json:"timeout"`
+	RecommendationLimit int           `json:"recommendation_limit"`
+	RateLimit           string        `json:"rate_limit"` // route dosyasındaki rate limit grubu
+}
+
+// GraphQLConfig gateway GraphQL endpoint'i konfigürasyonu
+type GraphQLConfig struct {
+	Enabled       bool          `json:"enabled"`
+	Path          string        `json:"path"`
+	Timeout       time.Duration `json:"timeout"`
+	MaxDepth      int           `json:"max_depth"`      // en fazla iç içe alan seviyesi
+	MaxComplexity int           `json:"max_complexity"` // liste boyutlarıyla çarpılmış toplam alan sayısı
+	RateLimit     string        `json:"rate_limit"`     // route dosyasındaki rate limit grubu
+}
+
+// CompressionConfig yanıt sıkıştırma ve sıkıştırılmış istek body'si konfigürasyonu
+type CompressionConfig struct {
+	Enabled         bool     `json:"enabled"`
+	MinBytes        int      `json:"min_bytes"`         // bu boyutun altındaki yanıtlar sıkıştırılmaz
+	Level           int      `json:"level"`             // 1 (hızlı) - 9 (en iyi); brotli için 0-11 aralığına ölçeklenir
+	ContentTypes    []string `json:"content_types"`     // sıkıştırılacak tipler; "text/*" gibi joker desteklenir
+	MaxRequestBytes int64    `json:"max_request_bytes"` // açılmış istek body'si için üst sınır
 }
 
-// LoadConfig konfigürasyonu yükler
-func LoadConfig() *Config {
-	return &Config{
+// HealthCheckConfig upstream instance'larının aktif health check konfigürasyonu
+type HealthCheckConfig struct {
+	Interval           time.Duration `json:"interval"`
+	Timeout            time.Duration `json:"timeout"`
+	Path               string        `json:"path"`
+	UnhealthyThreshold int           `json:"unhealthy_threshold"`
+	HealthyThreshold   int           `json:"healthy_threshold"`
+}
+
+// LoadConfig konfigürasyonu yükler ve route tablosunu doğrular
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "3000"),
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+			// Sadece bu proxy'lerden gelen X-Forwarded-For'a güvenilir (boş = istemci IP'si bağlantıdan alınır)
+			TrustedProxies: getEnvList("TRUSTED_PROXIES", ""),
 		},
 		Services: ServicesConfig{
-			BookServiceURL:          getEnv("BOOK_SERVICE_URL", "http://localhost:3001"),
-			AuthorServiceURL:        getEnv("AUTHOR_SERVICE_URL", "http://localhost:3002"),
-			GenreServiceURL:         getEnv("GENRE_SERVICE_URL", "http://localhost:3003"),
-			RecommendationServiceURL: getEnv("RECOMMENDATION_SERVICE_URL", "http://localhost:3004"),
-			AuthServiceURL:          getEnv("AUTH_SERVICE_URL", "http://localhost:3005"),
+			BookServiceURLs:           getEnvList("BOOK_SERVICE_URL", "http://localhost:3001"),
+			AuthorServiceURLs:         getEnvList("AUTHOR_SERVICE_URL", "http://localhost:3002"),
+			GenreServiceURLs:          getEnvList("GENRE_SERVICE_URL", "http://localhost:3003"),
+			RecommendationServiceURLs: getEnvList("RECOMMENDATION_SERVICE_URL", "http://localhost:3004"),
+			AuthServiceURLs:           getEnvList("AUTH_SERVICE_URL", "http://localhost:3005"),
 		},
 	}
+
+	cfg.JWT = JWTConfig{
+		SecretKey: getEnv("JWT_SECRET_KEY", "your-super-secret-jwt-key-change-this-in-production"),
+	}
+
+	cfg.Log = LogConfig{
+		Level: getEnv("LOG_LEVEL", "info"),
+	}
+
+	env := &envReader{}
+	cfg.Services.Extra = env.upstreams("EXTRA_UPSTREAMS")
+
+	cfg.Admin = AdminConfig{
+		Token:        getEnv("ADMIN_TOKEN", ""),
+		Host:         getEnv("ADMIN_HOST", "127.0.0.1"),
+		Port:         getEnv("ADMIN_PORT", "3100"),
+		AuditEntries: env.int("ADMIN_AUDIT_ENTRIES", 100),
+	}
+	cfg.Server.MaxHeaderBytes = env.int("SERVER_MAX_HEADER_BYTES", 64<<10)
+	cfg.Routing.ReloadInterval = env.duration("ROUTES_RELOAD_INTERVAL", "5s")
+	cfg.Routing.File = resolveRoutesFile()
+
+	cfg.Proxy = ProxyConfig{
+		ConnectTimeout:        env.duration("PROXY_CONNECT_TIMEOUT", "5s"),
+		ResponseHeaderTimeout: env.duration("PROXY_RESPONSE_HEADER_TIMEOUT", "15s"),
+		Timeout:               env.duration("PROXY_TIMEOUT", "30s"),
+		IdleTimeout:           env.duration("PROXY_IDLE_TIMEOUT", "60s"),
+		MaxBodyBytes:          int64(env.int("PROXY_MAX_BODY_BYTES", 10<<20)),
+	}
+
+	cfg.RateLimitStore = RateLimitStoreConfig{
+		FailOpen: env.bool("RATE_LIMIT_FAIL_OPEN", true),
+	}
+
+	cfg.Mirror = MirrorConfig{
+		LogFile:       getEnv("MIRROR_LOG_FILE", ""),
+		MaxConcurrent: env.int("MIRROR_MAX_CONCURRENT", 64),
+		MaxBodyBytes:  int64(env.int("MIRROR_MAX_BODY_BYTES", 1<<20)),
+		MaxDiffs:      env.int("MIRROR_MAX_DIFFS", 20),
+	}
+
+	// Varsayılan politika tüm origin'lere açıktır; credential'lı istekler için origin'ler tek tek listelenmelidir
+	cfg.CORS = CORSConfig{
+		AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", CORSAllowAll),
+		AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+		AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,X-Request-ID,traceparent,tracestate"),
+		ExposedHeaders:   getEnvList("CORS_EXPOSED_HEADERS", strings.Join(defaultCORSExposedHeaders, ",")),
+		AllowCredentials: env.bool("CORS_ALLOW_CREDENTIALS", false),
+		MaxAge:           env.duration("CORS_MAX_AGE", "12h"),
+	}
+
+	cfg.Registry = RegistryConfig{
+		Token: getEnv("REGISTRY_TOKEN", ""),
+		TTL:   env.duration("REGISTRY_TTL", "30s"),
+	}
+
+	cfg.Shutdown = ShutdownConfig{
+		Timeout:    env.duration("SHUTDOWN_TIMEOUT", "10s"),
+		DrainDelay: env.duration("SHUTDOWN_DRAIN_DELAY", "0s"),
+	}
+
+	cfg.CircuitBreaker = CircuitBreakerConfig{
+		Enabled:          env.bool("CIRCUIT_BREAKER_ENABLED", true),
+		FailureRatio:     env.float("CIRCUIT_BREAKER_FAILURE_RATIO", 0.5),
+		MinRequests:      env.int("CIRCUIT_BREAKER_MIN_REQUESTS", 10),
+		Window:           env.duration("CIRCUIT_BREAKER_WINDOW", "30s"),
+		CoolDown:         env.duration("CIRCUIT_BREAKER_COOL_DOWN", "15s"),
+		HalfOpenRequests: env.int("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 3),
+	}
+
+	cfg.Retry = RetryConfig{
+		Enabled:      env.bool("RETRY_ENABLED", true),
+		MaxRetries:   env.int("RETRY_MAX_RETRIES", 2),
+		BaseDelay:    env.duration("RETRY_BASE_DELAY", "100ms"),
+		MaxDelay:     env.duration("RETRY_MAX_DELAY", "2s"),
+		StatusCodes:  env.intList("RETRY_STATUS_CODES", "502,503,504"),
+		BudgetRatio:  env.float("RETRY_BUDGET_RATIO", 0.2),
+		BudgetBurst:  env.int("RETRY_BUDGET_BURST", 10),
+		MaxBodyBytes: int64(env.int("RETRY_MAX_BODY_BYTES", 1<<20)),
+	}
+
+	cfg.Cache = CacheConfig{
+		Enabled:       env.bool("CACHE_ENABLED", true),
+		MaxBytes:      int64(env.int("CACHE_MAX_BYTES", 64<<20)),
+		MaxEntryBytes: int64(env.int("CACHE_MAX_ENTRY_BYTES", 1<<20)),
+	}
+
+	cfg.HealthCheck = HealthCheckConfig{
+		Interval:           env.duration("HEALTH_CHECK_INTERVAL", "10s"),
+		Timeout:            env.duration("HEALTH_CHECK_TIMEOUT", "2s"),
+		Path:               getEnv("HEALTH_CHECK_PATH", "/health"),
+		UnhealthyThreshold: env.int("HEALTH_CHECK_UNHEALTHY_THRESHOLD", 3),
+		HealthyThreshold:   env.int("HEALTH_CHECK_HEALTHY_THRESHOLD", 2),
+	}
+
+	cfg.Metrics = MetricsConfig{
+		Enabled: env.bool("METRICS_ENABLED", true),
+		Path:    getEnv("METRICS_PATH", "/metrics"),
+	}
+
+	cfg.Tracing = TracingConfig{
+		Exporter:     getEnv("TRACING_EXPORTER", "none"),
+		FilePath:     getEnv("TRACING_FILE", "traces.jsonl"),
+		OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", ""),
+		SampleRatio:  env.float("TRACING_SAMPLE_RATIO", 1),
+	}
+
+	cfg.Composite = CompositeConfig{
+		Timeout:             env.duration("COMPOSITE_TIMEOUT", "5s"),
+		RecommendationLimit: env.int("COMPOSITE_RECOMMENDATION_LIMIT", 5),
+		RateLimit:           getEnv("COMPOSITE_RATE_LIMIT", DefaultRateLimitGroup),
+	}
+
+	cfg.GraphQL = GraphQLConfig{
+		Enabled:       env.bool("GRAPHQL_ENABLED", true),
+		Path:          getEnv("GRAPHQL_PATH", "/graphql"),
+		Timeout:       env.duration("GRAPHQL_TIMEOUT", "10s"),
+		MaxDepth:      env.int("GRAPHQL_MAX_DEPTH", 8),
+		MaxComplexity: env.int("GRAPHQL_MAX_COMPLEXITY", 500),
+		RateLimit:     getEnv("GRAPHQL_RATE_LIMIT", DefaultRateLimitGroup),
+	}
+
+	cfg.Compression = CompressionConfig{
+		Enabled:         env.bool("COMPRESSION_ENABLED", true),
+		MinBytes:        env.int("COMPRESSION_MIN_BYTES", 1024),
+		Level:           env.int("COMPRESSION_LEVEL", 5),
+		ContentTypes:    getEnvList("COMPRESSION_CONTENT_TYPES", "application/json,application/problem+json,application/graphql-response+json,application/javascript,application/xml,text/*,image/svg+xml"),
+		MaxRequestBytes: int64(env.int("COMPRESSION_MAX_REQUEST_BYTES", 10<<20)),
+	}
+
+	if env.err != nil {
+		return nil, env.err
+	}
+	if cfg.Server.MaxHeaderBytes <= 0 {
+		return nil, fmt.Errorf("SERVER_MAX_HEADER_BYTES pozitif olmalı")
+	}
+	if _, err := logging.ParseLevel(cfg.Log.Level); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL: %w", err)
+	}
+	if cfg.Admin.AuditEntries <= 0 {
+		return nil, fmt.Errorf("ADMIN_AUDIT_ENTRIES pozitif olmalı")
+	}
+	if cfg.Mirror.MaxConcurrent <= 0 || cfg.Mirror.MaxBodyBytes <= 0 || cfg.Mirror.MaxDiffs <= 0 {
+		return nil, fmt.Errorf("MIRROR_MAX_CONCURRENT, MIRROR_MAX_BODY_BYTES ve MIRROR_MAX_DIFFS pozitif olmalı")
+	}
+	if cfg.Registry.TTL < time.Second {
+		return nil, fmt.Errorf("REGISTRY_TTL en az 1s olmalı")
+	}
+	if cfg.Shutdown.Timeout <= 0 || cfg.Shutdown.DrainDelay < 0 {
+		return nil, fmt.Errorf("SHUTDOWN_TIMEOUT pozitif, SHUTDOWN_DRAIN_DELAY negatif olmayan bir süre olmalı")
+	}
+	if err := cfg.CORS.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Services.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Proxy.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.CircuitBreaker.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Retry.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.HealthCheck.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Tracing.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Composite.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.GraphQL.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Compression.validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Routing.File == "" {
+		log.Println("⚠️ Route dosyası bulunamadı, varsayılan route'lar kullanılacak")
+		cfg.Routing.Routes = DefaultRoutes()
+		cfg.Routing.Versioning = DefaultVersioning()
+		if err := ValidateRoutes(cfg.Routing.Routes, cfg.Routing.Versioning, cfg.Services); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+
+	file, err := LoadRoutes(cfg.Routing.File, cfg.Services)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Routing.Routes = file.Routes
+	cfg.Routing.Versioning = file.Versioning
+	cfg.Routing.RateLimits = file.RateLimits
+
+	// Route tablosu dışındaki gateway endpoint'lerinin rate limit grupları route dosyasında tanımlı olmalı
+	if err := validateEndpointRateLimit("COMPOSITE_RATE_LIMIT", cfg.Composite.RateLimit, file.RateLimits); err != nil {
+		return nil, err
+	}
+	if err := validateEndpointRateLimit("GRAPHQL_RATE_LIMIT", cfg.GraphQL.RateLimit, file.RateLimits); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateEndpointRateLimit grubun route dosyasında tanımlı olduğunu kontrol eder; route'larda olduğu gibi
+// tanımsız "default" grubu sınırsız anlamına gelir
+func validateEndpointRateLimit(key, group string, groups map[string]RateLimitConfig) error {
+	if _, ok := groups[group]; !ok && group != DefaultRateLimitGroup {
+		return fmt.Errorf("%s: bilinmeyen rate limit grubu: %s", key, group)
+	}
+	return nil
+}
+
+// validate proxy timeout ve boyut değerlerini doğrular
+func (c ProxyConfig) validate() error {
+	if c.ConnectTimeout <= 0 || c.ResponseHeaderTimeout <= 0 || c.Timeout <= 0 || c.IdleTimeout <= 0 {
+		return fmt.Errorf("PROXY_*_TIMEOUT değerleri pozitif olmalı")
+	}
+	if c.MaxBodyBytes <= 0 {
+		return fmt.Errorf("PROXY_MAX_BODY_BYTES pozitif olmalı")
+	}
+	return nil
+}
+
+// validate circuit breaker değerlerini doğrular
+func (c CircuitBreakerConfig) validate() error {
+	if c.FailureRatio <= 0 || c.FailureRatio > 1 {
+		return fmt.Errorf("CIRCUIT_BREAKER_FAILURE_RATIO 0-1 arasında olmalı: %v", c.FailureRatio)
+	}
+	if c.MinRequests < 1 || c.HalfOpenRequests < 1 {
+		return fmt.Errorf("circuit breaker istek sayıları en az 1 olmalı")
+	}
+	if c.Window <= 0 || c.CoolDown <= 0 {
+		return fmt.Errorf("circuit breaker süreleri pozitif olmalı")
+	}
+	return nil
+}
+
+// validate retry değerlerini doğrular
+func (c RetryConfig) validate() error {
+	if c.MaxRetries < 0 || c.BudgetBurst < 0 || c.MaxBodyBytes < 0 {
+		return fmt.Errorf("retry sayıları negatif olamaz")
+	}
+	if c.BaseDelay <= 0 || c.MaxDelay < c.BaseDelay {
+		return fmt.Errorf("RETRY_BASE_DELAY pozitif ve RETRY_MAX_DELAY'den küçük olmalı")
+	}
+	if c.BudgetRatio < 0 || c.BudgetRatio > 1 {
+		return fmt.Errorf("RETRY_BUDGET_RATIO 0-1 arasında olmalı: %v", c.BudgetRatio)
+	}
+	for _, code := range c.StatusCodes {
+		if code < 500 || code > 599 {
+			return fmt.Errorf("RETRY_STATUS_CODES sadece 5xx kodları içerebilir: %d", code)
+		}
+	}
+	return nil
+}
+
+// validate health check değerlerini doğrular
+func (c HealthCheckConfig) validate() error {
+	if c.Interval <= 0 || c.Timeout <= 0 {
+		return fmt.Errorf("health check süreleri pozitif olmalı")
+	}
+	if c.Timeout >= c.Interval {
+		return fmt.Errorf("HEALTH_CHECK_TIMEOUT, HEALTH_CHECK_INTERVAL'den küçük olmalı")
+	}
+	if c.UnhealthyThreshold < 1 || c.HealthyThreshold < 1 {
+		return fmt.Errorf("health check eşikleri en az 1 olmalı")
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("HEALTH_CHECK_PATH '/' ile başlamalı: %s", c.Path)
+	}
+	return nil
+}
+
+// validate tracing değerlerini doğrular
+func (c TracingConfig) validate() error {
+	switch c.Exporter {
+	case "none", "stdout", "file", "otlp":
+	default:
+		return fmt.Errorf("TRACING_EXPORTER none, stdout, file veya otlp olmalı: %s", c.Exporter)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO 0-1 arasında olmalı: %v", c.SampleRatio)
+	}
+	return nil
+}
+
+// validate composite değerlerini doğrular
+func (c CompositeConfig) validate() error {
+	if c.Timeout <= 0 {
+		return fmt.Errorf("COMPOSITE_TIMEOUT pozitif olmalı")
+	}
+	if c.RecommendationLimit < 1 || c.RecommendationLimit > 50 {
+		return fmt.Errorf("COMPOSITE_RECOMMENDATION_LIMIT 1-50 arasında olmalı: %d", c.RecommendationLimit)
+	}
+	return nil
+}
+
+// validate GraphQL değerlerini doğrular
+func (c GraphQLConfig) validate() error {
+	if !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("GRAPHQL_PATH '/' ile başlamalı: %s", c.Path)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("GRAPHQL_TIMEOUT pozitif olmalı")
+	}
+	if c.MaxDepth < 1 || c.MaxComplexity < 1 {
+		return fmt.Errorf("GRAPHQL_MAX_DEPTH ve GRAPHQL_MAX_COMPLEXITY en az 1 olmalı")
+	}
+	return nil
+}
+
+// validate sıkıştırma değerlerini doğrular
+func (c CompressionConfig) validate() error {
+	if c.MinBytes < 0 {
+		return fmt.Errorf("COMPRESSION_MIN_BYTES negatif olamaz: %d", c.MinBytes)
+	}
+	if c.Level < 1 || c.Level > 9 {
+		return fmt.Errorf("COMPRESSION_LEVEL 1-9 arasında olmalı: %d", c.Level)
+	}
+	if c.MaxRequestBytes <= 0 {
+		return fmt.Errorf("COMPRESSION_MAX_REQUEST_BYTES pozitif olmalı")
+	}
+	return nil
 }
 
 // GetServerAddress server adresini oluşturur
@@ -48,10 +543,67 @@ func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
 }
 
+// GetAdminAddress yönetim API'sinin dinlediği adresi oluşturur
+func (c *Config) GetAdminAddress() string {
+	return fmt.Sprintf("%s:%s", c.Admin.Host, c.Admin.Port)
+}
+
+// Loopback yönetim API'sinin sadece loopback arayüzünde dinleyip dinlemediğini kontrol eder
+func (a AdminConfig) Loopback() bool {
+	if a.Host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(a.Host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ByName servis adı -> instance URL'leri eşlemesini döner; EXTRA_UPSTREAMS ile tanımlanan havuzlar dahildir
+func (s ServicesConfig) ByName() map[string][]string {
+	services := map[string][]string{
+		"book-service":           s.BookServiceURLs,
+		"author-service":         s.AuthorServiceURLs,
+		"genre-service":          s.GenreServiceURLs,
+		"recommendation-service": s.RecommendationServiceURLs,
+		"auth-service":           s.AuthServiceURLs,
+	}
+	for name, urls := range s.Extra {
+		services[name] = urls
+	}
+	return services
+}
+
+// validate ek upstream adlarının servis adlarıyla çakışmadığını kontrol eder
+func (s ServicesConfig) validate() error {
+	builtin := ServicesConfig{}.ByName()
+	for name := range s.Extra {
+		if _, ok := builtin[name]; ok {
+			return fmt.Errorf("EXTRA_UPSTREAMS: %s servis adıyla çakışıyor, *_SERVICE_URL kullanılmalı", name)
+		}
+	}
+	return nil
+}
+
+// URLsFor servis adına göre instance URL'lerini döner
+func (s ServicesConfig) URLsFor(name string) ([]string, bool) {
+	urls, ok := s.ByName()[name]
+	return urls, ok && len(urls) > 0
+}
+
 // getEnv environment variable'ı okur, yoksa default değer döner
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
-} 
\ No newline at end of file
+}
+
+// getEnvList virgülle ayrılmış environment variable'ı liste olarak okur
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, strings.TrimSuffix(value, "/"))
+		}
+	}
+	return values
+}
@@ -0,0 +1,25 @@
+package configs
+
+import "testing"
+
+func TestAdminConfigLoopback(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "127.0.0.1", want: true},
+		{host: "::1", want: true},
+		{host: "localhost", want: true},
+		{host: "0.0.0.0", want: false},
+		{host: "", want: false},
+		{host: "10.0.0.5", want: false},
+		{host: "admin.example.com", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := (AdminConfig{Host: tt.host}).Loopback(); got != tt.want {
+				t.Errorf("Loopback(%q) = %v, beklenen %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
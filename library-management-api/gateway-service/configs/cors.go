@@ -0,0 +1,123 @@
+package configs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CORSAllowAll tüm origin'lere izin veren CORS_ALLOWED_ORIGINS değeri
+const CORSAllowAll = "*"
+
+// defaultCORSExposedHeaders gateway'in ürettiği ve tarayıcıdaki istemcilerin okuyabilmesi gereken yanıt header'ları
+var defaultCORSExposedHeaders = []string{
+	"X-Request-ID", HeaderAPIVersion, HeaderUpstreamVersion, "Deprecation", "Sunset", "Link",
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "X-Retry-Count", "X-Cache",
+}
+
+// CORSConfig gateway'in tüm yanıtlarına uygulanan CORS politikası; servisler kendi CORS header'larını üretmez
+type CORSConfig struct {
+	AllowedOrigins   []string      `json:"allowed_origins"` // tam origin, "*" veya https://*.example.com gibi alt alan adı deseni
+	AllowedMethods   []string      `json:"allowed_methods"`
+	AllowedHeaders   []string      `json:"allowed_headers"`
+	ExposedHeaders   []string      `json:"exposed_headers"`
+	AllowCredentials bool          `json:"allow_credentials"`
+	MaxAge           time.Duration `json:"max_age"` // preflight yanıtının tarayıcıda cache'lenme süresi
+
+	origins []originPattern
+}
+
+// originPattern ayrıştırılmış izinli origin; wildcard ise host alt alan adı son eki olarak karşılaştırılır
+type originPattern struct {
+	scheme   string
+	host     string
+	port     string
+	wildcard bool
+}
+
+// AllowsAllOrigins politikanın tüm origin'lere açık olup olmadığını döner
+func (c CORSConfig) AllowsAllOrigins() bool {
+	return len(c.AllowedOrigins) == 1 && c.AllowedOrigins[0] == CORSAllowAll
+}
+
+// AllowsOrigin origin'in izinli origin'lerden biriyle eşleşip eşleşmediğini kontrol eder.
+// https://*.example.com deseni https://api.example.com ve https://a.b.example.com ile eşleşir, https://example.com ile eşleşmez.
+func (c CORSConfig) AllowsOrigin(origin string) bool {
+	if c.AllowsAllOrigins() {
+		return true
+	}
+	candidate, err := parseOrigin(origin)
+	if err != nil || candidate.wildcard {
+		return false
+	}
+	for _, allowed := range c.origins {
+		if allowed.scheme != candidate.scheme || allowed.port != candidate.port {
+			continue
+		}
+		if allowed.host == candidate.host && !allowed.wildcard {
+			return true
+		}
+		if allowed.wildcard && strings.HasSuffix(candidate.host, "."+allowed.host) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate CORS değerlerini doğrular ve origin desenlerini ayrıştırır
+func (c *CORSConfig) validate() error {
+	if len(c.AllowedOrigins) == 0 {
+		return fmt.Errorf("CORS_ALLOWED_ORIGINS en az bir origin içermeli")
+	}
+	c.origins = nil
+	for _, origin := range c.AllowedOrigins {
+		if origin == CORSAllowAll {
+			if len(c.AllowedOrigins) > 1 {
+				return fmt.Errorf("CORS_ALLOWED_ORIGINS: \"*\" diğer origin'lerle birlikte kullanılamaz")
+			}
+			if c.AllowCredentials {
+				return fmt.Errorf("CORS_ALLOW_CREDENTIALS=true iken CORS_ALLOWED_ORIGINS \"*\" olamaz, tarayıcılar bu yanıtı reddeder")
+			}
+			continue
+		}
+		pattern, err := parseOrigin(origin)
+		if err != nil {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS: %w", err)
+		}
+		c.origins = append(c.origins, pattern)
+	}
+	if len(c.AllowedMethods) == 0 {
+		return fmt.Errorf("CORS_ALLOWED_METHODS en az bir method içermeli")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("CORS_MAX_AGE negatif olamaz")
+	}
+	return nil
+}
+
+// defaultPorts scheme'lerin varsayılan portları; origin'lerde yazılmış olmaları eşleşmeyi değiştirmez
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// parseOrigin scheme://host[:port] biçimindeki origin'i ayrıştırır; host "*." ile başlıyorsa wildcard desendir.
+// Varsayılan port atılır, böylece https://app.example.com:443 ile https://app.example.com aynı origin sayılır.
+func parseOrigin(origin string) (originPattern, error) {
+	parsed, err := url.Parse(strings.ToLower(origin))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return originPattern{}, fmt.Errorf("geçersiz origin: %q (scheme://host[:port] olmalı)", origin)
+	}
+
+	pattern := originPattern{scheme: parsed.Scheme, host: parsed.Hostname(), port: parsed.Port()}
+	if pattern.port == defaultPorts[pattern.scheme] {
+		pattern.port = ""
+	}
+	if strings.HasPrefix(pattern.host, "*.") {
+		pattern.wildcard = true
+		pattern.host = strings.TrimPrefix(pattern.host, "*.")
+	}
+	if pattern.host == "" || strings.Contains(pattern.host, "*") {
+		return originPattern{}, fmt.Errorf("geçersiz origin: %q (wildcard sadece en soldaki alt alan adı olabilir)", origin)
+	}
+	return pattern, nil
+}
@@ -0,0 +1,80 @@
+package configs
+
+import "testing"
+
+func newTestCORS(t *testing.T, origins ...string) CORSConfig {
+	t.Helper()
+	cors := CORSConfig{AllowedOrigins: origins, AllowedMethods: []string{"GET"}}
+	if err := cors.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	return cors
+}
+
+func TestCORSAllowsOrigin(t *testing.T) {
+	cors := newTestCORS(t, "https://app.example.com", "https://*.library.example.com", "http://localhost:5173", "https://admin.example.com:443")
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://app.example.com", want: true},
+		{origin: "https://APP.example.com", want: true},
+		{origin: "https://app.example.com:443", want: true},
+		{origin: "http://app.example.com", want: false},
+		{origin: "https://app.example.com:8443", want: false},
+		{origin: "https://evil-app.example.com", want: false},
+		{origin: "https://api.library.example.com", want: true},
+		{origin: "https://a.b.library.example.com", want: true},
+		{origin: "https://library.example.com", want: false},
+		{origin: "https://evillibrary.example.com", want: false},
+		{origin: "https://api.library.example.com.evil.com", want: false},
+		{origin: "http://localhost:5173", want: true},
+		{origin: "http://localhost:3000", want: false},
+		{origin: "https://admin.example.com", want: true},
+		{origin: "https://*.library.example.com", want: false},
+		{origin: "null", want: false},
+		{origin: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			if got := cors.AllowsOrigin(tt.origin); got != tt.want {
+				t.Errorf("AllowsOrigin(%q) = %v, beklenen %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+
+	all := newTestCORS(t, CORSAllowAll)
+	if !all.AllowsAllOrigins() || !all.AllowsOrigin("https://anything.example.org") {
+		t.Error("\"*\" tüm origin'lere izin vermedi")
+	}
+	if cors.AllowsAllOrigins() {
+		t.Error("origin listesi tüm origin'lere açık sayıldı")
+	}
+}
+
+func TestCORSValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cors    CORSConfig
+		wantErr bool
+	}{
+		{name: "origin yok", cors: CORSConfig{AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "method yok", cors: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}}, wantErr: true},
+		{name: "* ve credentials", cors: CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}, AllowCredentials: true}, wantErr: true},
+		{name: "* başka origin'le", cors: CORSConfig{AllowedOrigins: []string{"*", "https://app.example.com"}, AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "origin listesi ve credentials", cors: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowedMethods: []string{"GET"}, AllowCredentials: true}},
+		{name: "path içeren origin", cors: CORSConfig{AllowedOrigins: []string{"https://app.example.com/"}, AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "scheme'siz origin", cors: CORSConfig{AllowedOrigins: []string{"app.example.com"}, AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "ortadaki wildcard", cors: CORSConfig{AllowedOrigins: []string{"https://api.*.example.com"}, AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "çift wildcard", cors: CORSConfig{AllowedOrigins: []string{"https://*.*.example.com"}, AllowedMethods: []string{"GET"}}, wantErr: true},
+		{name: "negatif max age", cors: CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}, MaxAge: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cors.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate hatası = %v, hata bekleniyor = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
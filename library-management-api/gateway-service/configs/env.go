@@ -0,0 +1,104 @@
+package configs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envReader environment variable'larını tipli olarak okur ve karşılaşılan ilk hatayı saklar
+type envReader struct {
+	err error
+}
+
+// duration süre formatındaki (ör. 5s, 1m) değeri okur
+func (e *envReader) duration(key, defaultValue string) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, defaultValue))
+	if err != nil {
+		e.fail(key, err)
+	}
+	return value
+}
+
+// int tam sayı değeri okur
+func (e *envReader) int(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		e.fail(key, err)
+		return defaultValue
+	}
+	return value
+}
+
+// float ondalıklı sayı değeri okur
+func (e *envReader) float(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64)), 64)
+	if err != nil {
+		e.fail(key, err)
+		return defaultValue
+	}
+	return value
+}
+
+// bool true/false değeri okur
+func (e *envReader) bool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		e.fail(key, err)
+		return defaultValue
+	}
+	return value
+}
+
+// intList virgülle ayrılmış tam sayı listesini okur
+func (e *envReader) intList(key, defaultValue string) []int {
+	var values []int
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		value, err := strconv.Atoi(item)
+		if err != nil {
+			e.fail(key, err)
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// upstreams "ad=url1,url2;ad2=url3" formatındaki upstream tanımlarını okur
+func (e *envReader) upstreams(key string) map[string][]string {
+	upstreams := make(map[string][]string)
+	for _, item := range strings.Split(getEnv(key, ""), ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			e.fail(key, fmt.Errorf("upstream tanımı ad=url formatında olmalı: %q", item))
+			continue
+		}
+		var urls []string
+		for _, url := range strings.Split(list, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				urls = append(urls, strings.TrimSuffix(url, "/"))
+			}
+		}
+		if len(urls) == 0 {
+			e.fail(key, fmt.Errorf("%s upstream'i için en az bir URL verilmeli", name))
+			continue
+		}
+		upstreams[name] = urls
+	}
+	return upstreams
+}
+
+// fail ilk hatayı saklar
+func (e *envReader) fail(key string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("geçersiz %s: %w", key, err)
+	}
+}
@@ -0,0 +1,40 @@
+package configs
+
+import "fmt"
+
+// DefaultRateLimitGroup route'ta grup belirtilmediğinde kullanılan rate limit grubu
+const DefaultRateLimitGroup = "default"
+
+// Rate limit anahtar tipleri
+const (
+	RateLimitKeyIP    = "ip"
+	RateLimitKeyUser  = "user"
+	RateLimitKeyRoute = "route"
+)
+
+// RateLimitConfig token bucket rate limit grubu
+type RateLimitConfig struct {
+	Group             string  `json:"-"`
+	Key               string  `json:"key"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+// validate rate limit değerlerini doğrular
+func (l *RateLimitConfig) validate() error {
+	switch l.Key {
+	case "":
+		l.Key = RateLimitKeyIP
+	case RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyRoute:
+	default:
+		return fmt.Errorf("geçersiz rate limit anahtarı: %s (ip, user, route)", l.Key)
+	}
+
+	if l.RequestsPerSecond <= 0 {
+		return fmt.Errorf("requests_per_second pozitif olmalı")
+	}
+	if l.Burst < 1 {
+		return fmt.Errorf("burst en az 1 olmalı")
+	}
+	return nil
+}
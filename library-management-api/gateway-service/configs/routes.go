@@ -0,0 +1,634 @@
+package configs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Route auth politikaları
+const (
+	AuthNone     = "none"
+	AuthOptional = "optional"
+	AuthRequired = "required"
+)
+
+// HeaderUpstreamVersion traffic split kullanılan route'larda isteği karşılayan versiyonu istemciye bildiren header
+const HeaderUpstreamVersion = "X-Upstream-Version"
+
+// Traffic split sticky modları
+const (
+	StickyNone = ""
+	StickyUser = "user"
+)
+
+// Load balancing stratejileri
+const (
+	StrategyRoundRobin       = "round_robin"
+	StrategyLeastOutstanding = "least_outstanding"
+	StrategyConsistentHash   = "consistent_hash"
+)
+
+// defaultRoutesFiles route dosyası için sırayla denenen yollar (servis root'u veya cmd/server'dan çalıştırma)
+var defaultRoutesFiles = []string{
+	"configs/routes.json",
+	"../../configs/routes.json",
+}
+
+// RoutingConfig route tablosu konfigürasyonu
+type RoutingConfig struct {
+	File           string                     `json:"file"`
+	ReloadInterval time.Duration              `json:"reload_interval"`
+	Routes         []RouteConfig              `json:"routes"`
+	Versioning     *VersioningConfig          `json:"versioning"`
+	RateLimits     map[string]RateLimitConfig `json:"rate_limits"`
+}
+
+// RoutesFile route dosyasının içeriği
+type RoutesFile struct {
+	RateLimits map[string]RateLimitConfig `json:"rate_limits"`
+	Versioning *VersioningConfig          `json:"versioning"`
+	Routes     []RouteConfig              `json:"routes"`
+}
+
+// RouteConfig tek bir gateway route tanımı
+type RouteConfig struct {
+	Prefix        string   `json:"prefix"`
+	Upstream      string   `json:"upstream"`
+	StripPrefix   bool     `json:"strip_prefix"`
+	RewritePrefix string   `json:"rewrite_prefix"`
+	Methods       []string `json:"methods"`
+	Timeout       string   `json:"timeout"`
+	Version       string   `json:"version"` // boşsa route tüm API versiyonlarına hizmet eder
+
+	// Boş/0 bırakılan değerler için ProxyConfig varsayılanları kullanılır
+	ConnectTimeout        string `json:"connect_timeout"`
+	ResponseHeaderTimeout string `json:"response_header_timeout"`
+	IdleTimeout           string `json:"idle_timeout"` // SSE/WebSocket akışları için
+	MaxBodyBytes          int64  `json:"max_body_bytes"`
+	MaxHeaderBytes        int    `json:"max_header_bytes"` // SERVER_MAX_HEADER_BYTES'tan küçükse route'a özel 431 sınırı
+
+	LoadBalancer LoadBalancerConfig `json:"load_balancer"`
+	RateLimit    string             `json:"rate_limit"`
+	Auth         string             `json:"auth"`
+	Roles        []string           `json:"roles"`
+	Cache        *RouteCacheConfig  `json:"cache"`
+	Headers      *HeaderPolicy      `json:"headers"`
+	Split        *TrafficSplit      `json:"split"`
+	Mirror       *RouteMirrorConfig `json:"mirror"`
+
+	timeout               time.Duration
+	connectTimeout        time.Duration
+	responseHeaderTimeout time.Duration
+	idleTimeout           time.Duration
+	rateLimit             *RateLimitConfig
+
+	// Eşleşme sırasında isteğe göre doldurulur
+	versioning      *VersioningConfig
+	apiVersion      *APIVersion
+	explicitVersion bool
+}
+
+// RouteCacheConfig route için GET yanıt cache ayarları
+type RouteCacheConfig struct {
+	Enabled bool   `json:"enabled"`
+	TTL     string `json:"ttl"`
+
+	ttl time.Duration
+}
+
+// HeaderPolicy route için istemciden upstream'e ve upstream'den istemciye aktarılan header'ların politikası.
+// Varsayılan olarak hop-by-hop olmayan tüm header'lar aktarılır.
+type HeaderPolicy struct {
+	Request  HeaderRules `json:"request"`
+	Response HeaderRules `json:"response"`
+}
+
+// HeaderRules allow verilirse sadece listedeki header'lar geçer; deny listesindekiler her durumda çıkarılır
+type HeaderRules struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+// TrafficSplit route trafiğinin upstream versiyonları arasında bölünmesi (canary sürümler).
+// Header veya cookie'si eşleşen istekler ağırlıktan bağımsız olarak o versiyona gider; diğer istekler
+// ağırlıklara göre dağıtılır. sticky=user ile aynı kullanıcı her zaman aynı versiyona düşer.
+type TrafficSplit struct {
+	Sticky   string         `json:"sticky"`
+	Versions []RouteVersion `json:"versions"`
+
+	totalWeight int
+}
+
+// RouteVersion traffic split'teki tek bir upstream versiyonu
+type RouteVersion struct {
+	Name     string      `json:"name"`
+	Upstream string      `json:"upstream"`
+	Weight   int         `json:"weight"`
+	Header   *SplitMatch `json:"header"`
+	Cookie   *SplitMatch `json:"cookie"`
+}
+
+// SplitMatch isteği versiyona zorlayan header/cookie koşulu; value boşsa varlığı yeterlidir
+type SplitMatch struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// RouteMirrorConfig route'a gelen GET isteklerinin bir yüzdesinin gölge upstream'e de gönderilmesi.
+// Gölge yanıt istemciye dönmez; durum kodu, süre ve body farkları karşılaştırma kaydına yazılır.
+type RouteMirrorConfig struct {
+	Upstream   string  `json:"upstream"`
+	Percentage float64 `json:"percentage"` // 0-100 arası; kopyalanacak istek oranı
+
+	// Body karşılaştırmasında her seviyede yok sayılan JSON alanları (ör. zaman damgaları, istek ID'leri)
+	IgnoreFields []string `json:"ignore_fields"`
+}
+
+// LoadBalancerConfig route için upstream instance seçim politikası
+type LoadBalancerConfig struct {
+	Strategy   string `json:"strategy"`
+	HashHeader string `json:"hash_header"`
+}
+
+// LoadRoutes route dosyasını okur ve doğrular
+func LoadRoutes(path string, services ServicesConfig) (*RoutesFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("route dosyası okunamadı: %w", err)
+	}
+
+	var file RoutesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("route dosyası parse edilemedi (%s): %w", path, err)
+	}
+
+	if err := file.validate(services); err != nil {
+		return nil, fmt.Errorf("route dosyası geçersiz (%s): %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// ValidateRoutes route tanımlarını ve versiyonlamayı doğrular ve normalize eder
+func ValidateRoutes(routes []RouteConfig, versioning *VersioningConfig, services ServicesConfig) error {
+	file := &RoutesFile{Routes: routes, Versioning: versioning}
+	return file.validate(services)
+}
+
+// MatchRoute path'e uyan en uzun prefix'li route'un kopyasını döner.
+// Path'teki versiyon segmenti (/api/v2/books) eşleşmeden önce çıkarılır; versiyona özel route'lar
+// yalnızca kendi versiyonlarıyla, diğer route'lar tüm versiyonlarla eşleşir.
+func MatchRoute(routes []RouteConfig, versioning *VersioningConfig, path string) (*RouteConfig, bool) {
+	version, unversioned, explicit := versioning.Resolve(path)
+
+	// Route'lar prefix uzunluğuna göre sıralı tutulur
+	for i := range routes {
+		if routes[i].Version != "" && (version == nil || routes[i].Version != version.Name) {
+			continue
+		}
+		if routes[i].Matches(unversioned) {
+			route := routes[i]
+			route.versioning = versioning
+			route.apiVersion = version
+			route.explicitVersion = explicit
+			return &route, true
+		}
+	}
+	return nil, false
+}
+
+// validate route dosyasındaki grupları ve route'ları doğrular, route'ları gruplarına bağlar
+func (f *RoutesFile) validate(services ServicesConfig) error {
+	for name, limit := range f.RateLimits {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("rate limit grubu %s: %w", name, err)
+		}
+		f.RateLimits[name] = limit
+	}
+
+	if f.Versioning != nil {
+		if err := f.Versioning.validate(); err != nil {
+			return err
+		}
+	}
+
+	routes := f.Routes
+	if len(routes) == 0 {
+		return fmt.Errorf("en az bir route tanımlanmalı")
+	}
+
+	seen := make(map[string]bool)
+	for i := range routes {
+		route := &routes[i]
+
+		if err := route.validate(services); err != nil {
+			return fmt.Errorf("route #%d (%s): %w", i+1, route.Prefix, err)
+		}
+		if err := route.bindRateLimit(f.RateLimits); err != nil {
+			return fmt.Errorf("route #%d (%s): %w", i+1, route.Prefix, err)
+		}
+		if route.Version != "" {
+			if _, ok := f.Versioning.versions()[route.Version]; !ok {
+				return fmt.Errorf("route #%d (%s): tanımsız versiyon: %s", i+1, route.Prefix, route.Version)
+			}
+		}
+		key := route.Prefix + "@" + route.Version
+		if seen[key] {
+			return fmt.Errorf("route #%d: %s prefix'i birden fazla tanımlanmış", i+1, route.Prefix)
+		}
+		seen[key] = true
+	}
+
+	// En uzun prefix önce eşleşsin; aynı prefix'te versiyona özel route genel route'tan önce gelir
+	sort.SliceStable(routes, func(i, j int) bool {
+		if len(routes[i].Prefix) != len(routes[j].Prefix) {
+			return len(routes[i].Prefix) > len(routes[j].Prefix)
+		}
+		return routes[i].Version != "" && routes[j].Version == ""
+	})
+
+	return nil
+}
+
+// validate tek route'u doğrular
+func (r *RouteConfig) validate(services ServicesConfig) error {
+	if len(r.Prefix) > 1 {
+		r.Prefix = strings.TrimSuffix(r.Prefix, "/")
+	}
+	if !strings.HasPrefix(r.Prefix, "/") {
+		return fmt.Errorf("prefix '/' ile başlamalı")
+	}
+
+	if r.Upstream == "" {
+		return fmt.Errorf("upstream boş olamaz")
+	}
+	if _, ok := services.URLsFor(r.Upstream); !ok {
+		return fmt.Errorf("bilinmeyen upstream: %s", r.Upstream)
+	}
+
+	if r.StripPrefix && r.RewritePrefix != "" {
+		return fmt.Errorf("strip_prefix ve rewrite_prefix birlikte kullanılamaz")
+	}
+	if r.RewritePrefix != "" && !strings.HasPrefix(r.RewritePrefix, "/") {
+		return fmt.Errorf("rewrite_prefix '/' ile başlamalı")
+	}
+
+	for i, method := range r.Methods {
+		method = strings.ToUpper(method)
+		if !isKnownMethod(method) {
+			return fmt.Errorf("geçersiz HTTP metodu: %s", r.Methods[i])
+		}
+		r.Methods[i] = method
+	}
+
+	switch r.LoadBalancer.Strategy {
+	case "":
+		r.LoadBalancer.Strategy = StrategyRoundRobin
+	case StrategyRoundRobin, StrategyLeastOutstanding:
+	case StrategyConsistentHash:
+		if r.LoadBalancer.HashHeader == "" {
+			return fmt.Errorf("consistent_hash stratejisi için hash_header gerekli")
+		}
+	default:
+		return fmt.Errorf("geçersiz load balancer stratejisi: %s", r.LoadBalancer.Strategy)
+	}
+
+	switch r.Auth {
+	case "":
+		r.Auth = AuthNone
+	case AuthNone, AuthOptional, AuthRequired:
+	default:
+		return fmt.Errorf("geçersiz auth politikası: %s (none, optional, required)", r.Auth)
+	}
+	if len(r.Roles) > 0 && r.Auth != AuthRequired {
+		return fmt.Errorf("roles sadece auth=required ile kullanılabilir")
+	}
+
+	if r.Cache != nil && r.Cache.TTL != "" {
+		ttl, err := time.ParseDuration(r.Cache.TTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("geçersiz cache ttl: %s", r.Cache.TTL)
+		}
+		r.Cache.ttl = ttl
+	}
+
+	if r.Headers != nil {
+		if err := r.Headers.Request.compile(); err != nil {
+			return fmt.Errorf("headers.request: %w", err)
+		}
+		if err := r.Headers.Response.compile(); err != nil {
+			return fmt.Errorf("headers.response: %w", err)
+		}
+	}
+
+	if r.Split != nil {
+		if err := r.Split.validate(services); err != nil {
+			return fmt.Errorf("split: %w", err)
+		}
+		if r.CacheEnabled() {
+			return fmt.Errorf("split kullanılan route'ta cache açılamaz (versiyonların yanıtları karışır)")
+		}
+	}
+
+	if r.Mirror != nil {
+		if _, ok := services.URLsFor(r.Mirror.Upstream); !ok {
+			return fmt.Errorf("mirror: bilinmeyen upstream: %s", r.Mirror.Upstream)
+		}
+		if r.Mirror.Percentage <= 0 || r.Mirror.Percentage > 100 {
+			return fmt.Errorf("mirror: percentage 0'dan büyük ve en fazla 100 olmalı: %v", r.Mirror.Percentage)
+		}
+	}
+
+	var err error
+	if r.timeout, err = parseTimeout("timeout", r.Timeout); err != nil {
+		return err
+	}
+	if r.connectTimeout, err = parseTimeout("connect_timeout", r.ConnectTimeout); err != nil {
+		return err
+	}
+	if r.responseHeaderTimeout, err = parseTimeout("response_header_timeout", r.ResponseHeaderTimeout); err != nil {
+		return err
+	}
+	if r.idleTimeout, err = parseTimeout("idle_timeout", r.IdleTimeout); err != nil {
+		return err
+	}
+	if r.MaxBodyBytes < 0 || r.MaxHeaderBytes < 0 {
+		return fmt.Errorf("max_body_bytes ve max_header_bytes negatif olamaz")
+	}
+
+	return nil
+}
+
+// validate versiyonları ve ağırlıkları doğrular
+func (t *TrafficSplit) validate(services ServicesConfig) error {
+	switch t.Sticky {
+	case StickyNone, StickyUser:
+	default:
+		return fmt.Errorf("geçersiz sticky: %s (user veya boş)", t.Sticky)
+	}
+	if len(t.Versions) == 0 {
+		return fmt.Errorf("en az bir versiyon tanımlanmalı")
+	}
+
+	seen := make(map[string]bool)
+	t.totalWeight = 0
+	for i := range t.Versions {
+		version := &t.Versions[i]
+		if version.Name == "" {
+			return fmt.Errorf("versiyon #%d: name boş olamaz", i+1)
+		}
+		if seen[version.Name] {
+			return fmt.Errorf("%s versiyonu birden fazla tanımlanmış", version.Name)
+		}
+		seen[version.Name] = true
+
+		if _, ok := services.URLsFor(version.Upstream); !ok {
+			return fmt.Errorf("%s versiyonu: bilinmeyen upstream: %s", version.Name, version.Upstream)
+		}
+		if version.Weight < 0 {
+			return fmt.Errorf("%s versiyonu: weight negatif olamaz", version.Name)
+		}
+		if (version.Header != nil && version.Header.Name == "") || (version.Cookie != nil && version.Cookie.Name == "") {
+			return fmt.Errorf("%s versiyonu: header/cookie koşulunda name boş olamaz", version.Name)
+		}
+		if version.Header != nil {
+			version.Header.Name = http.CanonicalHeaderKey(version.Header.Name)
+		}
+		t.totalWeight += version.Weight
+	}
+	if t.totalWeight == 0 {
+		return fmt.Errorf("versiyon ağırlıklarının toplamı pozitif olmalı")
+	}
+	return nil
+}
+
+// TotalWeight versiyon ağırlıklarının toplamını döner
+func (t *TrafficSplit) TotalWeight() int {
+	return t.totalWeight
+}
+
+// Pick [0, TotalWeight) aralığındaki değerin düştüğü versiyonu döner. Versiyonlar tanım sırasıyla
+// ardışık aralıklara yerleşir; canary son sırada tanımlanırsa ağırlığı artırıldığında mevcut canary
+// kullanıcıları canary'de kalır.
+func (t *TrafficSplit) Pick(point int) *RouteVersion {
+	for i := range t.Versions {
+		if point < t.Versions[i].Weight {
+			return &t.Versions[i]
+		}
+		point -= t.Versions[i].Weight
+	}
+	return &t.Versions[len(t.Versions)-1]
+}
+
+// parseTimeout route timeout alanını parse eder; boş değer için 0 döner
+func parseTimeout(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("geçersiz %s: %s", field, value)
+	}
+	return timeout, nil
+}
+
+// bindRateLimit route'u rate limit grubuna bağlar; grup belirtilmemişse "default" grubu kullanılır
+func (r *RouteConfig) bindRateLimit(groups map[string]RateLimitConfig) error {
+	name := r.RateLimit
+	if name == "" {
+		name = DefaultRateLimitGroup
+	}
+
+	limit, ok := groups[name]
+	if !ok {
+		if r.RateLimit != "" {
+			return fmt.Errorf("bilinmeyen rate limit grubu: %s", r.RateLimit)
+		}
+		return nil
+	}
+
+	limit.Group = name
+	r.rateLimit = &limit
+	return nil
+}
+
+// compile header adlarını kanonik hale getirip arama tablolarını oluşturur
+func (h *HeaderRules) compile() error {
+	h.allow = make(map[string]bool, len(h.Allow))
+	h.deny = make(map[string]bool, len(h.Deny))
+	for _, name := range h.Allow {
+		if name == "" {
+			return fmt.Errorf("boş header adı")
+		}
+		h.allow[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, name := range h.Deny {
+		if name == "" {
+			return fmt.Errorf("boş header adı")
+		}
+		h.deny[http.CanonicalHeaderKey(name)] = true
+	}
+	return nil
+}
+
+// Permits kanonik header adının kurallardan geçip geçmediğini kontrol eder
+func (h HeaderRules) Permits(name string) bool {
+	if h.deny[name] {
+		return false
+	}
+	return len(h.allow) == 0 || h.allow[name]
+}
+
+// RequestHeaders route'un istemci header'ları için kurallarını döner (boş = hepsi aktarılır)
+func (r *RouteConfig) RequestHeaders() HeaderRules {
+	if r.Headers == nil {
+		return HeaderRules{}
+	}
+	return r.Headers.Request
+}
+
+// ResponseHeaders route'un upstream yanıt header'ları için kurallarını döner (boş = hepsi aktarılır)
+func (r *RouteConfig) ResponseHeaders() HeaderRules {
+	if r.Headers == nil {
+		return HeaderRules{}
+	}
+	return r.Headers.Response
+}
+
+// CacheEnabled route'ta GET yanıt cache'inin açık olup olmadığını döner
+func (r *RouteConfig) CacheEnabled() bool {
+	return r.Cache != nil && r.Cache.Enabled
+}
+
+// CacheTTL route için TTL override'ını döner (0 = upstream Cache-Control/Expires kullanılır)
+func (r *RouteConfig) CacheTTL() time.Duration {
+	if r.Cache == nil {
+		return 0
+	}
+	return r.Cache.ttl
+}
+
+// AuthPolicy route'un auth politikasını döner
+func (r *RouteConfig) AuthPolicy() string {
+	if r.Auth == "" {
+		return AuthNone
+	}
+	return r.Auth
+}
+
+// RateLimitPolicy route'a uygulanan rate limit'i döner (nil = sınırsız)
+func (r *RouteConfig) RateLimitPolicy() *RateLimitConfig {
+	return r.rateLimit
+}
+
+// Matches path'in route prefix'ine segment sınırında uyup uymadığını kontrol eder
+func (r *RouteConfig) Matches(path string) bool {
+	if !strings.HasPrefix(path, r.Prefix) {
+		return false
+	}
+	rest := path[len(r.Prefix):]
+	return rest == "" || strings.HasPrefix(rest, "/") || r.Prefix == "/"
+}
+
+// AllowsMethod route'un verilen metodu kabul edip etmediğini kontrol eder (boş liste tüm metodlara izin verir)
+func (r *RouteConfig) AllowsMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// APIVersion isteğin eşleştiği API versiyonunu döner (nil = versiyonlama kapalı)
+func (r *RouteConfig) APIVersion() *APIVersion {
+	return r.apiVersion
+}
+
+// RequestPrefix route prefix'ini istemcinin kullandığı haliyle döner (ör. /api/v1/books); metrik etiketi olarak kullanılır
+func (r *RouteConfig) RequestPrefix() string {
+	if !r.explicitVersion {
+		return r.Prefix
+	}
+	return r.versioning.versioned(r.Prefix, r.apiVersion.Name)
+}
+
+// RewritePath upstream'e gönderilecek path'i oluşturur; versiyon segmenti upstream'e aktarılmaz
+func (r *RouteConfig) RewritePath(path string) string {
+	_, path, _ = r.versioning.Resolve(path)
+	if !r.StripPrefix && r.RewritePrefix == "" {
+		return path
+	}
+
+	rewritten := strings.TrimSuffix(r.RewritePrefix, "/") + strings.TrimPrefix(path, r.Prefix)
+	if !strings.HasPrefix(rewritten, "/") {
+		rewritten = "/" + rewritten
+	}
+	return rewritten
+}
+
+// Limits route'un tanımladığı timeout ve body sınırlarını varsayılanların üzerine uygulayarak döner
+func (r *RouteConfig) Limits(defaults ProxyConfig) ProxyConfig {
+	limits := defaults
+	if r.timeout > 0 {
+		limits.Timeout = r.timeout
+	}
+	if r.connectTimeout > 0 {
+		limits.ConnectTimeout = r.connectTimeout
+	}
+	if r.responseHeaderTimeout > 0 {
+		limits.ResponseHeaderTimeout = r.responseHeaderTimeout
+	}
+	if r.idleTimeout > 0 {
+		limits.IdleTimeout = r.idleTimeout
+	}
+	if r.MaxBodyBytes > 0 {
+		limits.MaxBodyBytes = r.MaxBodyBytes
+	}
+	return limits
+}
+
+// DefaultRoutes route dosyası bulunamadığında kullanılan varsayılan route'lar
+func DefaultRoutes() []RouteConfig {
+	return []RouteConfig{
+		{Prefix: "/api/books", Upstream: "book-service"},
+		{Prefix: "/api/authors", Upstream: "author-service"},
+		{Prefix: "/api/genres", Upstream: "genre-service"},
+		{Prefix: "/api/recommendations", Upstream: "recommendation-service"},
+		{Prefix: "/api/auth", Upstream: "auth-service"},
+	}
+}
+
+// resolveRoutesFile kullanılacak route dosyasının yolunu bulur
+func resolveRoutesFile() string {
+	if path := os.Getenv("ROUTES_FILE"); path != "" {
+		return path
+	}
+	for _, path := range defaultRoutesFiles {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// isKnownMethod HTTP metodunun geçerli olup olmadığını kontrol eder
+func isKnownMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace:
+		return true
+	}
+	return false
+}
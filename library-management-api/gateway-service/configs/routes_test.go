@@ -0,0 +1,199 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMatchRoute(t *testing.T) {
+	routes := []RouteConfig{
+		{Prefix: "/api/books", Upstream: "book-service"},
+		{Prefix: "/api/books/search/", Upstream: "book-service", Methods: []string{"get"}},
+		{Prefix: "/api/authors", Upstream: "author-service"},
+	}
+	if err := ValidateRoutes(routes, nil, testServices); err != nil {
+		t.Fatalf("ValidateRoutes: %v", err)
+	}
+
+	tests := []struct {
+		path       string
+		wantPrefix string
+	}{
+		{path: "/api/books", wantPrefix: "/api/books"},
+		{path: "/api/books/42", wantPrefix: "/api/books"},
+		{path: "/api/books/search", wantPrefix: "/api/books/search"},
+		{path: "/api/books/search/isbn", wantPrefix: "/api/books/search"},
+		{path: "/api/bookshelf", wantPrefix: ""},
+		{path: "/api/genres", wantPrefix: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			route, ok := MatchRoute(routes, nil, tt.path)
+			if tt.wantPrefix == "" {
+				if ok {
+					t.Errorf("%s route'u ile eşleşmemeliydi", route.Prefix)
+				}
+				return
+			}
+			if !ok || route.Prefix != tt.wantPrefix {
+				t.Errorf("eşleşen route = %v, beklenen %s", route, tt.wantPrefix)
+			}
+			if route.APIVersion() != nil {
+				t.Errorf("versiyonlama kapalıyken versiyon döndü: %v", route.APIVersion())
+			}
+		})
+	}
+}
+
+func TestRouteRewritePathAndMethods(t *testing.T) {
+	tests := []struct {
+		name        string
+		route       RouteConfig
+		path        string
+		want        string
+		method      string
+		wantAllowed bool
+	}{
+		{
+			name:        "prefix korunur",
+			route:       RouteConfig{Prefix: "/api/books", Upstream: "book-service"},
+			path:        "/api/books/1",
+			want:        "/api/books/1",
+			method:      "DELETE",
+			wantAllowed: true,
+		},
+		{
+			name:        "strip_prefix",
+			route:       RouteConfig{Prefix: "/api/books", Upstream: "book-service", StripPrefix: true, Methods: []string{"GET"}},
+			path:        "/api/books",
+			want:        "/",
+			method:      "GET",
+			wantAllowed: true,
+		},
+		{
+			name:        "rewrite_prefix",
+			route:       RouteConfig{Prefix: "/api/books", Upstream: "book-service", RewritePrefix: "/internal/books/", Methods: []string{"get", "post"}},
+			path:        "/api/books/1",
+			want:        "/internal/books/1",
+			method:      "PUT",
+			wantAllowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := []RouteConfig{tt.route}
+			if err := ValidateRoutes(routes, nil, testServices); err != nil {
+				t.Fatalf("ValidateRoutes: %v", err)
+			}
+			if got := routes[0].RewritePath(tt.path); got != tt.want {
+				t.Errorf("RewritePath = %s, beklenen %s", got, tt.want)
+			}
+			if got := routes[0].AllowsMethod(tt.method); got != tt.wantAllowed {
+				t.Errorf("AllowsMethod(%s) = %v, beklenen %v", tt.method, got, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestValidateRoutesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		route RouteConfig
+	}{
+		{name: "prefix / ile başlamıyor", route: RouteConfig{Prefix: "api/books", Upstream: "book-service"}},
+		{name: "bilinmeyen upstream", route: RouteConfig{Prefix: "/api/books", Upstream: "library-service"}},
+		{name: "strip ve rewrite birlikte", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", StripPrefix: true, RewritePrefix: "/books"}},
+		{name: "geçersiz metod", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Methods: []string{"FETCH"}}},
+		{name: "bilinmeyen strateji", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", LoadBalancer: LoadBalancerConfig{Strategy: "random"}}},
+		{name: "hash_header eksik", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", LoadBalancer: LoadBalancerConfig{Strategy: StrategyConsistentHash}}},
+		{name: "servisin instance'ı yok", route: RouteConfig{Prefix: "/api/genres", Upstream: "genre-service"}},
+		{name: "rate limit grubu tanımsız", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", RateLimit: "strict"}},
+		{name: "geçersiz auth politikası", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Auth: "always"}},
+		{name: "roles auth=required olmadan", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Auth: AuthOptional, Roles: []string{"admin"}}},
+		{name: "geçersiz cache ttl", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Cache: &RouteCacheConfig{Enabled: true, TTL: "soon"}}},
+		{name: "tanımsız versiyon", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Version: "v2"}},
+		{name: "boş header adı", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Headers: &HeaderPolicy{Request: HeaderRules{Deny: []string{""}}}}},
+		{name: "geçersiz connect_timeout", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", ConnectTimeout: "0s"}},
+		{name: "negatif body sınırı", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", MaxBodyBytes: -1}},
+		{name: "split versiyonu yok", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Split: &TrafficSplit{}}},
+		{
+			name: "split ile cache",
+			route: RouteConfig{
+				Prefix: "/api/books", Upstream: "book-service",
+				Split: &TrafficSplit{Versions: []RouteVersion{{Name: "canary", Upstream: "book-service-v2", Weight: 10}}},
+				Cache: &RouteCacheConfig{Enabled: true},
+			},
+		},
+		{name: "split upstream'i bilinmiyor", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Split: &TrafficSplit{Versions: []RouteVersion{{Name: "canary", Upstream: "library-service", Weight: 10}}}}},
+		{name: "mirror upstream'i bilinmiyor", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Mirror: &RouteMirrorConfig{Upstream: "library-service", Percentage: 10}}},
+		{name: "mirror oranı sınır dışında", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Mirror: &RouteMirrorConfig{Upstream: "book-service-v2", Percentage: 150}}},
+		{name: "geçersiz timeout", route: RouteConfig{Prefix: "/api/books", Upstream: "book-service", Timeout: "-1s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRoutes([]RouteConfig{tt.route}, nil, testServices); err == nil {
+				t.Error("geçersiz route kabul edildi")
+			}
+		})
+	}
+
+	duplicate := []RouteConfig{
+		{Prefix: "/api/books", Upstream: "book-service"},
+		{Prefix: "/api/books/", Upstream: "book-service"},
+	}
+	if err := ValidateRoutes(duplicate, nil, testServices); err == nil {
+		t.Error("aynı prefix iki kez kabul edildi")
+	}
+}
+
+func TestLoadRoutes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "routes.json")
+	data := `{
+		"rate_limits": {"default": {"key": "ip", "requests_per_second": 5, "burst": 10}},
+		"routes": [
+			{"prefix": "/api/books", "upstream": "book-service", "timeout": "5s", "rate_limit": "default"},
+			{"prefix": "/api/books/admin", "upstream": "book-service", "cache": {"enabled": true, "ttl": "30s"}}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("route dosyası yazılamadı: %v", err)
+	}
+
+	file, err := LoadRoutes(path, testServices)
+	if err != nil {
+		t.Fatalf("LoadRoutes: %v", err)
+	}
+	routes := file.Routes
+	if routes[0].Prefix != "/api/books/admin" {
+		t.Errorf("route'lar en uzun prefix'e göre sıralanmadı: %s", routes[0].Prefix)
+	}
+	if !routes[0].CacheEnabled() || routes[0].CacheTTL() != 30*time.Second {
+		t.Errorf("cache ayarı = %v, %v; beklenen açık, 30s", routes[0].CacheEnabled(), routes[0].CacheTTL())
+	}
+	if routes[1].CacheEnabled() {
+		t.Error("cache ayarı olmayan route'ta cache açık")
+	}
+	if routes[0].LoadBalancer.Strategy != StrategyRoundRobin {
+		t.Errorf("varsayılan strateji = %s, beklenen %s", routes[0].LoadBalancer.Strategy, StrategyRoundRobin)
+	}
+	limits := routes[1].Limits(ProxyConfig{Timeout: time.Minute, ConnectTimeout: time.Second, MaxBodyBytes: 1 << 20})
+	if limits.Timeout != 5*time.Second || limits.ConnectTimeout != time.Second || limits.MaxBodyBytes != 1<<20 {
+		t.Errorf("route limitleri = %+v, beklenen timeout 5s ve diğerleri varsayılan", limits)
+	}
+	if policy := routes[1].RateLimitPolicy(); policy == nil || policy.Burst != 10 {
+		t.Errorf("rate limit grubu route'a bağlanmadı: %+v", policy)
+	}
+	if _, ok := file.RateLimits[DefaultRateLimitGroup]; !ok {
+		t.Error("rate limit grupları route dosyasından okunmadı")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"routes": [`), 0o600); err != nil {
+		t.Fatalf("route dosyası yazılamadı: %v", err)
+	}
+	if _, err := LoadRoutes(path, testServices); err == nil {
+		t.Error("bozuk route dosyası kabul edildi")
+	}
+}
@@ -0,0 +1,159 @@
+package configs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderAPIVersion isteğin hangi API versiyonuyla karşılandığını istemciye ve upstream'e bildiren header
+const HeaderAPIVersion = "X-API-Version"
+
+// defaultVersionPrefix versiyon segmentinin arandığı varsayılan path prefix'i
+const defaultVersionPrefix = "/api"
+
+// VersioningConfig route dosyasındaki API versiyonlama ayarları.
+// /api/v2/books gibi istekler versiyon segmenti çıkarılarak (/api/books) route tablosunda eşlenir;
+// versiyonsuz istekler varsayılan versiyona yönlendirilir.
+type VersioningConfig struct {
+	Prefix   string                 `json:"prefix"`
+	Default  string                 `json:"default"`
+	Versions map[string]*APIVersion `json:"versions"`
+}
+
+// APIVersion tek bir API versiyonunun yaşam döngüsü bilgisi
+type APIVersion struct {
+	Name        string `json:"-"`
+	Deprecation string `json:"deprecation"` // RFC 3339; verilirse versiyon deprecated sayılır
+	Sunset      string `json:"sunset"`      // RFC 3339; versiyonun kaldırılacağı tarih
+	Link        string `json:"link"`        // geçiş dokümanı
+
+	deprecation time.Time
+	sunset      time.Time
+}
+
+// DefaultVersioning route dosyası bulunamadığında kullanılan versiyonlama (sadece v1)
+func DefaultVersioning() *VersioningConfig {
+	return &VersioningConfig{
+		Default:  "v1",
+		Versions: map[string]*APIVersion{"v1": {}},
+	}
+}
+
+// validate versiyon tanımlarını doğrular ve tarihleri parse eder
+func (c *VersioningConfig) validate() error {
+	if c.Prefix == "" {
+		c.Prefix = defaultVersionPrefix
+	}
+	c.Prefix = strings.TrimSuffix(c.Prefix, "/")
+	if !strings.HasPrefix(c.Prefix, "/") {
+		return fmt.Errorf("versioning prefix '/' ile başlamalı: %s", c.Prefix)
+	}
+
+	if len(c.Versions) == 0 {
+		return fmt.Errorf("versioning için en az bir versiyon tanımlanmalı")
+	}
+	if _, ok := c.Versions[c.Default]; !ok {
+		return fmt.Errorf("varsayılan versiyon tanımlı değil: %q", c.Default)
+	}
+
+	for name, version := range c.Versions {
+		if name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("geçersiz versiyon adı: %q", name)
+		}
+		if version == nil {
+			version = &APIVersion{}
+			c.Versions[name] = version
+		}
+		version.Name = name
+		if err := version.validate(); err != nil {
+			return fmt.Errorf("versiyon %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validate deprecation ve sunset tarihlerini parse eder
+func (v *APIVersion) validate() error {
+	var err error
+	if v.Deprecation != "" {
+		if v.deprecation, err = time.Parse(time.RFC3339, v.Deprecation); err != nil {
+			return fmt.Errorf("geçersiz deprecation tarihi (RFC 3339 olmalı): %s", v.Deprecation)
+		}
+	}
+	if v.Sunset != "" {
+		if v.sunset, err = time.Parse(time.RFC3339, v.Sunset); err != nil {
+			return fmt.Errorf("geçersiz sunset tarihi (RFC 3339 olmalı): %s", v.Sunset)
+		}
+		if !v.deprecation.IsZero() && v.sunset.Before(v.deprecation) {
+			return fmt.Errorf("sunset tarihi deprecation tarihinden önce olamaz")
+		}
+	}
+	return nil
+}
+
+// Resolve path'teki versiyon segmentini çözer: /api/v2/books -> (v2, /api/books, true).
+// Versiyonsuz veya tanımsız versiyonlu path'ler varsayılan versiyonla ve değiştirilmeden döner (explicit=false).
+// Versiyonlama kapalıysa (nil) versiyon nil döner.
+func (c *VersioningConfig) Resolve(path string) (version *APIVersion, unversioned string, explicit bool) {
+	if c == nil {
+		return nil, path, false
+	}
+
+	if rest, ok := strings.CutPrefix(path, c.Prefix+"/"); ok {
+		segment, tail, _ := strings.Cut(rest, "/")
+		if v, ok := c.Versions[segment]; ok {
+			unversioned = c.Prefix
+			if tail != "" || strings.HasSuffix(rest, "/") {
+				unversioned += "/" + tail
+			}
+			return v, unversioned, true
+		}
+	}
+	return c.Versions[c.Default], path, false
+}
+
+// versioned versiyonsuz path'e versiyon segmentini ekler: (/api/books, v2) -> /api/v2/books
+func (c *VersioningConfig) versioned(path, name string) string {
+	if c == nil {
+		return path
+	}
+	if rest, ok := strings.CutPrefix(path, c.Prefix); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		return c.Prefix + "/" + name + rest
+	}
+	return path
+}
+
+// versions tanımlı versiyonları döner (versiyonlama kapalıysa nil)
+func (c *VersioningConfig) versions() map[string]*APIVersion {
+	if c == nil {
+		return nil
+	}
+	return c.Versions
+}
+
+// Deprecated versiyonun deprecated olup olmadığını döner
+func (v *APIVersion) Deprecated() bool {
+	return !v.deprecation.IsZero()
+}
+
+// ResponseHeaders versiyon için istemciye dönülecek header'ları oluşturur:
+// X-API-Version, deprecated versiyonlarda Deprecation (RFC 9745), Sunset (RFC 8594) ve Link.
+func (v *APIVersion) ResponseHeaders() http.Header {
+	header := http.Header{}
+	header.Set(HeaderAPIVersion, v.Name)
+	if v.Deprecated() {
+		header.Set("Deprecation", "@"+strconv.FormatInt(v.deprecation.Unix(), 10))
+	}
+	if !v.sunset.IsZero() {
+		header.Set("Sunset", v.sunset.UTC().Format(http.TimeFormat))
+	}
+	if v.Link != "" && v.Deprecated() {
+		header.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", v.Link))
+	} else if v.Link != "" && !v.sunset.IsZero() {
+		header.Add("Link", fmt.Sprintf("<%s>; rel=\"sunset\"", v.Link))
+	}
+	return header
+}
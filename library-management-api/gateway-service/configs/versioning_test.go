@@ -0,0 +1,166 @@
+package configs
+
+import (
+	"net/http"
+	"testing"
+)
+
+// testServices test route'larının kullandığı upstream havuzları
+var testServices = ServicesConfig{
+	BookServiceURLs:           []string{"http://localhost:3001"},
+	AuthorServiceURLs:         []string{"http://localhost:3002"},
+	RecommendationServiceURLs: []string{"http://localhost:3004"},
+	Extra:                     map[string][]string{"book-service-v2": {"http://localhost:3011"}},
+}
+
+func newTestVersioning(t *testing.T) *VersioningConfig {
+	t.Helper()
+	versioning := &VersioningConfig{
+		Default: "v1",
+		Versions: map[string]*APIVersion{
+			"v1": {Deprecation: "2026-01-01T00:00:00Z", Sunset: "2027-01-01T00:00:00Z", Link: "https://docs.example.com/v2"},
+			"v2": nil,
+		},
+	}
+	if err := versioning.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	return versioning
+}
+
+func TestVersioningResolve(t *testing.T) {
+	versioning := newTestVersioning(t)
+
+	tests := []struct {
+		path            string
+		wantVersion     string
+		wantUnversioned string
+		wantExplicit    bool
+	}{
+		{path: "/api/v2/books", wantVersion: "v2", wantUnversioned: "/api/books", wantExplicit: true},
+		{path: "/api/v1/books/42", wantVersion: "v1", wantUnversioned: "/api/books/42", wantExplicit: true},
+		{path: "/api/v2", wantVersion: "v2", wantUnversioned: "/api", wantExplicit: true},
+		{path: "/api/v2/", wantVersion: "v2", wantUnversioned: "/api/", wantExplicit: true},
+		{path: "/api/books", wantVersion: "v1", wantUnversioned: "/api/books"},
+		{path: "/api/v3/books", wantVersion: "v1", wantUnversioned: "/api/v3/books"},
+		{path: "/apiv2/books", wantVersion: "v1", wantUnversioned: "/apiv2/books"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			version, unversioned, explicit := versioning.Resolve(tt.path)
+			if version.Name != tt.wantVersion || unversioned != tt.wantUnversioned || explicit != tt.wantExplicit {
+				t.Errorf("Resolve = (%s, %s, %v), beklenen (%s, %s, %v)",
+					version.Name, unversioned, explicit, tt.wantVersion, tt.wantUnversioned, tt.wantExplicit)
+			}
+		})
+	}
+
+	var disabled *VersioningConfig
+	if version, path, explicit := disabled.Resolve("/api/v2/books"); version != nil || path != "/api/v2/books" || explicit {
+		t.Errorf("versiyonlama kapalıyken path değişti: %v %s %v", version, path, explicit)
+	}
+}
+
+func TestVersioningValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		versioning VersioningConfig
+		wantErr    bool
+	}{
+		{name: "geçerli", versioning: VersioningConfig{Default: "v1", Versions: map[string]*APIVersion{"v1": {}}}},
+		{name: "versiyon yok", versioning: VersioningConfig{Default: "v1"}, wantErr: true},
+		{name: "varsayılan tanımsız", versioning: VersioningConfig{Default: "v2", Versions: map[string]*APIVersion{"v1": {}}}, wantErr: true},
+		{name: "prefix / ile başlamıyor", versioning: VersioningConfig{Prefix: "api", Default: "v1", Versions: map[string]*APIVersion{"v1": {}}}, wantErr: true},
+		{name: "geçersiz tarih", versioning: VersioningConfig{Default: "v1", Versions: map[string]*APIVersion{"v1": {Sunset: "yarın"}}}, wantErr: true},
+		{
+			name: "sunset deprecation'dan önce",
+			versioning: VersioningConfig{Default: "v1", Versions: map[string]*APIVersion{
+				"v1": {Deprecation: "2027-01-01T00:00:00Z", Sunset: "2026-01-01T00:00:00Z"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.versioning.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate hatası = %v, hata bekleniyor = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchRouteVersioned(t *testing.T) {
+	versioning := newTestVersioning(t)
+	routes := []RouteConfig{
+		{Prefix: "/api/books", Upstream: "book-service", StripPrefix: true},
+		{Prefix: "/api/books", Upstream: "book-service-v2", Version: "v2", RewritePrefix: "/v2/books"},
+		{Prefix: "/api/authors", Upstream: "author-service"},
+	}
+	if err := ValidateRoutes(routes, versioning, testServices); err != nil {
+		t.Fatalf("ValidateRoutes: %v", err)
+	}
+
+	tests := []struct {
+		path          string
+		wantUpstream  string
+		wantVersion   string
+		wantPrefix    string
+		wantRewritten string
+	}{
+		{path: "/api/books/1", wantUpstream: "book-service", wantVersion: "v1", wantPrefix: "/api/books", wantRewritten: "/1"},
+		{path: "/api/v1/books/1", wantUpstream: "book-service", wantVersion: "v1", wantPrefix: "/api/v1/books", wantRewritten: "/1"},
+		{path: "/api/v2/books/1", wantUpstream: "book-service-v2", wantVersion: "v2", wantPrefix: "/api/v2/books", wantRewritten: "/v2/books/1"},
+		{path: "/api/v2/books", wantUpstream: "book-service-v2", wantVersion: "v2", wantPrefix: "/api/v2/books", wantRewritten: "/v2/books"},
+		{path: "/api/v2/authors", wantUpstream: "author-service", wantVersion: "v2", wantPrefix: "/api/v2/authors", wantRewritten: "/api/authors"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			route, ok := MatchRoute(routes, versioning, tt.path)
+			if !ok {
+				t.Fatal("route eşleşmedi")
+			}
+			if route.Upstream != tt.wantUpstream || route.APIVersion().Name != tt.wantVersion {
+				t.Errorf("eşleşme = (%s, %s), beklenen (%s, %s)", route.Upstream, route.APIVersion().Name, tt.wantUpstream, tt.wantVersion)
+			}
+			if got := route.RequestPrefix(); got != tt.wantPrefix {
+				t.Errorf("RequestPrefix = %s, beklenen %s", got, tt.wantPrefix)
+			}
+			if got := route.RewritePath(tt.path); got != tt.wantRewritten {
+				t.Errorf("RewritePath = %s, beklenen %s", got, tt.wantRewritten)
+			}
+		})
+	}
+}
+
+func TestAPIVersionResponseHeaders(t *testing.T) {
+	versioning := newTestVersioning(t)
+
+	tests := []struct {
+		version string
+		want    http.Header
+	}{
+		{
+			version: "v1",
+			want: http.Header{
+				HeaderAPIVersion: {"v1"},
+				"Deprecation":    {"@1767225600"},
+				"Sunset":         {"Fri, 01 Jan 2027 00:00:00 GMT"},
+				"Link":           {`<https://docs.example.com/v2>; rel="deprecation"`},
+			},
+		},
+		{version: "v2", want: http.Header{HeaderAPIVersion: {"v2"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got := versioning.Versions[tt.version].ResponseHeaders()
+			if len(got) != len(tt.want) {
+				t.Fatalf("header'lar = %v, beklenen %v", got, tt.want)
+			}
+			for name, values := range tt.want {
+				if got.Get(name) != values[0] {
+					t.Errorf("%s = %q, beklenen %q", name, got.Get(name), values[0])
+				}
+			}
+		})
+	}
+}
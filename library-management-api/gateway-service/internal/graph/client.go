@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gateway-service/internal/service"
+)
+
+// errUnauthorized auth-service token'ı kabul etmediğinde döner
+var errUnauthorized = errors.New("bu alan için geçerli bir token gerekli")
+
+// upstreamClient resolver'ların REST servislerini istemcinin header'larıyla çağırdığı yardımcı
+type upstreamClient struct {
+	proxy  service.ProxyService
+	header http.Header
+}
+
+// get servis yanıtının data alanını out'a çözer. Kayıt yoksa (404) false döner, hata dönmez.
+func (c *upstreamClient) get(ctx context.Context, upstream, path string, out interface{}) (bool, error) {
+	body, found, err := c.fetch(ctx, upstream, path)
+	if err != nil || !found {
+		return false, err
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Data) == 0 {
+		return false, fmt.Errorf("%s yanıtında data alanı yok", upstream)
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return false, fmt.Errorf("%s yanıtı parse edilemedi: %v", upstream, err)
+	}
+	return true, nil
+}
+
+// getRaw envelope kullanmayan servislerin (auth-service) yanıtını doğrudan out'a çözer
+func (c *upstreamClient) getRaw(ctx context.Context, upstream, path string, out interface{}) (bool, error) {
+	body, found, err := c.fetch(ctx, upstream, path)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return false, fmt.Errorf("%s yanıtı parse edilemedi: %v", upstream, err)
+	}
+	return true, nil
+}
+
+// fetch upstream çağrısını yapar ve durum kodunu GraphQL alan hatasına çevirir
+func (c *upstreamClient) fetch(ctx context.Context, upstream, path string) ([]byte, bool, error) {
+	resp, err := c.proxy.Fetch(ctx, upstream, path, c.header)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s kullanılamıyor (%s)", upstream, service.UpstreamErrorReason(ctx, err))
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return resp.Body, true, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, false, errUnauthorized
+	default:
+		return nil, false, fmt.Errorf("%s beklenmeyen durum kodu döndü: %d", upstream, resp.StatusCode)
+	}
+}
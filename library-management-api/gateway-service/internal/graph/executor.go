@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// ErrInvalidQuery sorgu parse, doğrulama veya derinlik/karmaşıklık kontrolünden geçemediğinde döner
+var ErrInvalidQuery = errors.New("geçersiz GraphQL sorgusu")
+
+// Request GraphQL over HTTP istek gövdesi
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor gateway şeması üzerinde GraphQL sorgularını çalıştırır
+type Executor struct {
+	schema graphql.Schema
+	proxy  service.ProxyService
+	config configs.GraphQLConfig
+}
+
+// NewExecutor şemayı oluşturur ve yeni executor döner
+func NewExecutor(proxy service.ProxyService, config configs.GraphQLConfig) (*Executor, error) {
+	schema, err := newSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{
+		schema: schema,
+		proxy:  proxy,
+		config: config,
+	}, nil
+}
+
+// Execute sorguyu parse eder, doğrular, derinlik/karmaşıklık sınırlarını kontrol eder ve çalıştırır.
+// Sorgu çalıştırılmadan reddedilirse hatalar sonuçla birlikte ErrInvalidQuery döner.
+// header'daki istemci header'ları (ör. Authorization) resolver'ların upstream çağrılarına aktarılır.
+func (e *Executor) Execute(ctx context.Context, req Request, header http.Header) (*graphql.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.config.Timeout)
+	defer cancel()
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return e.reject(ctx, gqlerrors.FormatErrors(err))
+	}
+	if validation := graphql.ValidateDocument(&e.schema, doc, nil); !validation.IsValid {
+		return e.reject(ctx, validation.Errors)
+	}
+	if err := checkLimits(&e.schema, doc, req.OperationName, req.Variables, e.config.MaxDepth, e.config.MaxComplexity); err != nil {
+		return e.reject(ctx, gqlerrors.FormatErrors(err))
+	}
+
+	client := &upstreamClient{proxy: e.proxy, header: header}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, stateKey{}, newRequestState(ctx, client)),
+	})
+	result.Extensions = extensions(ctx)
+	return result, nil
+}
+
+// reject çalıştırılmayan sorgu için hata sonucunu döner
+func (e *Executor) reject(ctx context.Context, errs []gqlerrors.FormattedError) (*graphql.Result, error) {
+	requestid.Warnf(ctx, "⚠️ [graphql] Sorgu reddedildi: %s", errs[0].Message)
+	return &graphql.Result{Errors: errs, Extensions: extensions(ctx)}, ErrInvalidQuery
+}
+
+// extensions yanıta istek ID'sini ekler; hata bildirimlerinde log'larla ilişkilendirmek için kullanılır
+func extensions(ctx context.Context) map[string]interface{} {
+	return map[string]interface{}{"request_id": requestid.FromContext(ctx)}
+}
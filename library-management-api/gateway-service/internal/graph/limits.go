@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listSizeArgs liste boyutunu belirleyen argümanlar; alanın alt seçimlerinin maliyeti bu değerle çarpılır
+var listSizeArgs = []string{"limit", "pageSize"}
+
+// queryCost doğrulanmış bir operasyonun derinliğini ve karmaşıklığını hesaplar.
+// Her alan 1 puandır; limit/pageSize argümanı olan alanlarda alt seçimlerin puanı liste boyutuyla çarpılır.
+// Introspection alanları (__schema, __type, __typename) hesaba katılmaz.
+type queryCost struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value
+}
+
+// checkLimits operasyonun derinlik ve karmaşıklık sınırlarını aşıp aşmadığını kontrol eder
+func checkLimits(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}, maxDepth, maxComplexity int) error {
+	cost := &queryCost{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+		defaults:  make(map[string]ast.Value),
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			cost.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		// Operasyon seçimi hatası executor tarafından raporlanır
+		return nil
+	}
+	for _, definition := range operation.VariableDefinitions {
+		if definition.DefaultValue != nil {
+			cost.defaults[definition.Variable.Name.Value] = definition.DefaultValue
+		}
+	}
+
+	// Şemada yalnızca Query kök tipi var; diğer operasyon tipleri doğrulamada reddedilir
+	depth, complexity := cost.selectionSet(operation.SelectionSet, schema.QueryType())
+	if depth > maxDepth {
+		return fmt.Errorf("sorgu derinliği %d, en fazla %d olabilir", depth, maxDepth)
+	}
+	if complexity > maxComplexity {
+		return fmt.Errorf("sorgu karmaşıklığı %d, en fazla %d olabilir", complexity, maxComplexity)
+	}
+	return nil
+}
+
+// selectionSet seçim kümesinin derinliğini ve toplam maliyetini döner
+func (q *queryCost) selectionSet(set *ast.SelectionSet, parent graphql.Type) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	maxDepth, total := 0, 0
+	for _, selection := range set.Selections {
+		var depth, cost int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			depth, cost = q.field(sel, parent)
+		case *ast.InlineFragment:
+			depth, cost = q.selectionSet(sel.SelectionSet, q.typeCondition(sel.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			if fragment, ok := q.fragments[sel.Name.Value]; ok {
+				depth, cost = q.selectionSet(fragment.SelectionSet, q.typeCondition(fragment.TypeCondition, parent))
+			}
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		total += cost
+	}
+	return maxDepth, total
+}
+
+// field tek bir alanın derinliğini ve maliyetini döner
+func (q *queryCost) field(field *ast.Field, parent graphql.Type) (int, int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return 1, 1
+	}
+	definition, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	depth, cost := q.selectionSet(field.SelectionSet, graphql.GetNamed(definition.Type).(graphql.Type))
+	return depth + 1, 1 + q.listSize(field, definition)*cost
+}
+
+// listSize alanın limit/pageSize argümanını (sorgudaki değer, değişken veya şema varsayılanı) döner; yoksa 1
+func (q *queryCost) listSize(field *ast.Field, definition *graphql.FieldDefinition) int {
+	for _, name := range listSizeArgs {
+		for _, arg := range field.Arguments {
+			if arg.Name.Value == name {
+				if size, ok := q.intValue(arg.Value); ok {
+					return max(size, 1)
+				}
+			}
+		}
+		for _, arg := range definition.Args {
+			if arg.Name() == name {
+				if size, ok := arg.DefaultValue.(int); ok {
+					return size
+				}
+			}
+		}
+	}
+	return 1
+}
+
+// intValue argüman değerini sayıya çevirir; değişkenler istek değerinden veya varsayılanından okunur
+func (q *queryCost) intValue(value ast.Value) (int, bool) {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		return n, err == nil
+	case *ast.Variable:
+		switch n := q.variables[v.Name.Value].(type) {
+		case int:
+			return n, true
+		case float64:
+			return int(n), true
+		}
+		if def, ok := q.defaults[v.Name.Value]; ok {
+			return q.intValue(def)
+		}
+	}
+	return 0, false
+}
+
+// typeCondition fragment'ın tip koşulunu şemadan çözer; koşul yoksa üst tip kullanılır
+func (q *queryCost) typeCondition(condition *ast.Named, parent graphql.Type) graphql.Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if t, ok := q.schema.TypeMap()[condition.Name.Value]; ok {
+		return t
+	}
+	return parent
+}
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// newLimitsSchema maliyet hesaplarının elle doğrulanabileceği küçük bir şema oluşturur
+func newLimitsSchema(t *testing.T) *graphql.Schema {
+	t.Helper()
+	author := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Author",
+		Fields: graphql.Fields{"id": {Type: graphql.ID}, "name": {Type: graphql.String}},
+	})
+	book := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":     {Type: graphql.ID},
+			"title":  {Type: graphql.String},
+			"author": {Type: author},
+		},
+	})
+	author.AddFieldConfig("books", &graphql.Field{
+		Type: graphql.NewList(book),
+		Args: graphql.FieldConfigArgument{"limit": {Type: graphql.Int, DefaultValue: 5}},
+	})
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": {Type: book, Args: graphql.FieldConfigArgument{"id": {Type: graphql.ID}}},
+			"books": {
+				Type: graphql.NewList(book),
+				Args: graphql.FieldConfigArgument{"limit": {Type: graphql.Int, DefaultValue: 10}},
+			},
+			"search": {
+				Type: graphql.NewList(book),
+				Args: graphql.FieldConfigArgument{"pageSize": {Type: graphql.Int}},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatalf("şema oluşturulamadı: %v", err)
+	}
+	return &schema
+}
+
+func TestCheckLimits(t *testing.T) {
+	schema := newLimitsSchema(t)
+
+	tests := []struct {
+		name           string
+		query          string
+		operation      string
+		variables      map[string]interface{}
+		wantDepth      int
+		wantComplexity int
+	}{
+		{name: "tek nesne", query: `{ book(id: 1) { id title } }`, wantDepth: 2, wantComplexity: 3},
+		{name: "şema varsayılan limit", query: `{ books { id } }`, wantDepth: 2, wantComplexity: 11},
+		{name: "sorgudaki limit", query: `{ books(limit: 3) { id title } }`, wantDepth: 2, wantComplexity: 7},
+		{name: "pageSize argümanı", query: `{ search(pageSize: 4) { id } }`, wantDepth: 2, wantComplexity: 5},
+		{name: "sıfır limit en az bir sayılır", query: `{ books(limit: 0) { id } }`, wantDepth: 2, wantComplexity: 2},
+		{
+			name:      "değişkenden limit",
+			query:     `query($n: Int) { books(limit: $n) { id } }`,
+			variables: map[string]interface{}{"n": float64(4)}, wantDepth: 2, wantComplexity: 5,
+		},
+		{name: "değişken varsayılanı", query: `query($n: Int = 2) { books(limit: $n) { id } }`, wantDepth: 2, wantComplexity: 3},
+		{
+			name:      "iç içe listeler çarpılır",
+			query:     `{ books(limit: 2) { author { books(limit: 3) { id } } } }`,
+			wantDepth: 4, wantComplexity: 11,
+		},
+		{
+			name:      "fragment spread",
+			query:     `{ books(limit: 2) { ...Details } } fragment Details on Book { id author { name } }`,
+			wantDepth: 3, wantComplexity: 7,
+		},
+		{name: "inline fragment", query: `{ book(id: 1) { ... on Book { id title } } }`, wantDepth: 2, wantComplexity: 3},
+		{name: "introspection alanları sayılmaz", query: `{ __typename books(limit: 1) { __typename id } }`, wantDepth: 2, wantComplexity: 2},
+		{
+			name:      "operasyon adına göre seçim",
+			query:     `query Small { book(id: 1) { id } } query Large { books(limit: 50) { id } }`,
+			operation: "Small", wantDepth: 2, wantComplexity: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("sorgu parse edilemedi: %v", err)
+			}
+
+			// Sınırlar tam hesaplanan değerdeyken geçer, bir eksiğinde reddedilir
+			if err := checkLimits(schema, doc, tt.operation, tt.variables, tt.wantDepth, tt.wantComplexity); err != nil {
+				t.Errorf("sınırda reddedildi: %v", err)
+			}
+			if err := checkLimits(schema, doc, tt.operation, tt.variables, tt.wantDepth-1, tt.wantComplexity); err == nil {
+				t.Errorf("derinlik %d iken %d sınırı aşılmadı", tt.wantDepth, tt.wantDepth-1)
+			}
+			if err := checkLimits(schema, doc, tt.operation, tt.variables, tt.wantDepth, tt.wantComplexity-1); err == nil {
+				t.Errorf("karmaşıklık %d iken %d sınırı aşılmadı", tt.wantComplexity, tt.wantComplexity-1)
+			}
+		})
+	}
+}
+
+func TestCheckLimitsUnknownOperation(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `query A { books { id } }`})
+	if err != nil {
+		t.Fatalf("sorgu parse edilemedi: %v", err)
+	}
+	// Operasyon bulunamazsa hata executor'a bırakılır
+	if err := checkLimits(newLimitsSchema(t), doc, "B", nil, 1, 1); err != nil {
+		t.Errorf("bilinmeyen operasyon için limit hatası döndü: %v", err)
+	}
+}
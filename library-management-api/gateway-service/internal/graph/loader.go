@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// maxConcurrentFetches bir batch içinde aynı anda upstream'e gönderilen en fazla istek sayısı
+const maxConcurrentFetches = 8
+
+// Loader tek bir GraphQL isteği boyunca kullanılan dataloader.
+// Aynı seviyedeki resolver'ların istediği anahtarlar toplanır ve ilk değer okunduğunda hepsi birlikte
+// (eşzamanlı olarak) getirilir; aynı anahtar istek boyunca yalnızca bir kez upstream'e sorulur.
+// Servislerde toplu (batch) endpoint olmadığı için batch, anahtar başına paralel isteklerle yapılır.
+type Loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, key K) (V, error)
+
+	mu      sync.Mutex
+	entries map[K]*loaderEntry[V]
+	pending []K
+}
+
+// loaderEntry bir anahtarın sonucu; done kapandıktan sonra value ve err okunabilir
+type loaderEntry[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+// NewLoader istek context'ine bağlı yeni loader oluşturur
+func NewLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, key K) (V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		entries: make(map[K]*loaderEntry[V]),
+	}
+}
+
+// Load anahtarı bir sonraki batch'e ekler ve sonucu döndüren thunk'ı verir.
+// graphql-go thunk'ları seviye seviye çözdüğü için aynı seviyedeki tüm Load çağrıları tek batch'te toplanır.
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &loaderEntry[V]{done: make(chan struct{})}
+		l.entries[key] = entry
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.dispatch()
+		<-entry.done
+		return entry.value, entry.err
+	}
+}
+
+// Prime başka bir yanıttan zaten bilinen değeri cache'e ekler; anahtar daha önce istenmişse dokunmaz
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.entries[key]; ok {
+		return
+	}
+	entry := &loaderEntry[V]{value: value, done: make(chan struct{})}
+	close(entry.done)
+	l.entries[key] = entry
+}
+
+// dispatch bekleyen tüm anahtarları eşzamanlı olarak getirir
+func (l *Loader[K, V]) dispatch() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	batch := make([]*loaderEntry[V], len(keys))
+	for i, key := range keys {
+		batch[i] = l.entries[key]
+	}
+	l.mu.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetches)
+	for i, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key K, entry *loaderEntry[V]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			entry.value, entry.err = l.fetch(l.ctx, key)
+			close(entry.done)
+		}(key, batch[i])
+	}
+	wg.Wait()
+}
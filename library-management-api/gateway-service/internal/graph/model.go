@@ -0,0 +1,91 @@
+package graph
+
+// Model alanları GraphQL alan adlarıyla eşleşir (graphql-go varsayılan resolver'ı alan adını
+// büyük/küçük harf duyarsız karşılaştırır); json tag'leri REST servislerinin yanıtlarını çözmek içindir.
+
+// Book book-service kitap kaydı
+type Book struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Publisher    string `json:"publisher"`
+	AuthorName   string `json:"author"`
+	CategoryName string `json:"category_name"`
+	ProductCode  string `json:"product_code"`
+	PageCount    int    `json:"page_count"`
+	ReleasedYear int    `json:"released_year"`
+}
+
+// Author author-service yazar kaydı
+type Author struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Genre genre-service tür kaydı
+type Genre struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Recommendation recommendation-service öneri kaydı
+type Recommendation struct {
+	Book   *Book  `json:"book"`
+	Reason string `json:"reason"`
+	Score  int    `json:"score"`
+}
+
+// User auth-service kullanıcı kaydı
+type User struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// BookPage sayfalı kitap listesi
+type BookPage struct {
+	Books      []*Book `json:"books"`
+	Total      int     `json:"total"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"page_size"`
+	TotalPages int     `json:"total_pages"`
+}
+
+// AuthorPage sayfalı yazar listesi
+type AuthorPage struct {
+	Authors    []*Author `json:"authors"`
+	Total      int       `json:"total"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalPages int       `json:"total_pages"`
+}
+
+// GenrePage sayfalı tür listesi
+type GenrePage struct {
+	Genres     []*Genre `json:"genres"`
+	Total      int      `json:"total"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"page_size"`
+	TotalPages int      `json:"total_pages"`
+}
+
+// authorDetail /api/authors/detail/:name yanıtı
+type authorDetail struct {
+	Author    Author  `json:"author"`
+	Books     []*Book `json:"books"`
+	BookCount int     `json:"book_count"`
+}
+
+// genreDetail /api/genres/detail/:name yanıtı
+type genreDetail struct {
+	Genre     Genre   `json:"genre"`
+	Books     []*Book `json:"books"`
+	BookCount int     `json:"book_count"`
+}
+
+// recommendationList recommendation-service liste yanıtı
+type recommendationList struct {
+	Recommendations []*Recommendation `json:"recommendations"`
+}
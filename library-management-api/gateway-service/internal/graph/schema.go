@@ -0,0 +1,458 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+// genreDetailPageSize tür detayında istenen kitap sayısı (genre-service üst sınırı)
+const genreDetailPageSize = 50
+
+// maxListLimit liste argümanlarının (limit, pageSize) kabul edilen en büyük değeri
+const maxListLimit = 50
+
+// requestState tek bir GraphQL isteğine ait upstream istemcisi ve dataloader'lar
+type requestState struct {
+	client  *upstreamClient
+	books   *Loader[int, *Book]
+	authors *Loader[string, *authorDetail]
+	genres  *Loader[string, *genreDetail]
+}
+
+// stateKey requestState'in context anahtarı
+type stateKey struct{}
+
+// newRequestState istek için yeni loader'lar oluşturur; loader'lar istekler arasında paylaşılmaz
+func newRequestState(ctx context.Context, client *upstreamClient) *requestState {
+	return &requestState{
+		client: client,
+		books: NewLoader(ctx, func(ctx context.Context, id int) (*Book, error) {
+			var book Book
+			found, err := client.get(ctx, "book-service", fmt.Sprintf("/api/books/simple/%d", id), &book)
+			if !found {
+				return nil, err
+			}
+			return &book, nil
+		}),
+		authors: NewLoader(ctx, func(ctx context.Context, name string) (*authorDetail, error) {
+			var detail authorDetail
+			found, err := client.get(ctx, "author-service", "/api/authors/detail/"+url.PathEscape(name), &detail)
+			if !found {
+				return nil, err
+			}
+			return &detail, nil
+		}),
+		genres: NewLoader(ctx, func(ctx context.Context, name string) (*genreDetail, error) {
+			var detail genreDetail
+			path := fmt.Sprintf("/api/genres/detail/%s?page_size=%d", url.PathEscape(name), genreDetailPageSize)
+			found, err := client.get(ctx, "genre-service", path, &detail)
+			if !found {
+				return nil, err
+			}
+			return &detail, nil
+		}),
+	}
+}
+
+// state resolver context'indeki istek durumunu döner
+func state(p graphql.ResolveParams) *requestState {
+	return p.Context.Value(stateKey{}).(*requestState)
+}
+
+// newSchema Book, Author, Genre, Recommendation ve User tiplerini REST servislerine bağlayan şemayı oluşturur
+func newSchema() (graphql.Schema, error) {
+	var bookType, authorType, genreType *graphql.Object
+
+	limitArg := func(defaultValue int) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{
+			"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultValue},
+		}
+	}
+	pageArgs := graphql.FieldConfigArgument{
+		"page":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+		"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+		"search":   &graphql.ArgumentConfig{Type: graphql.String},
+	}
+
+	bookType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Book",
+		Description: "book-service kitap kaydı",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"title":        &graphql.Field{Type: graphql.String},
+				"publisher":    &graphql.Field{Type: graphql.String},
+				"authorName":   &graphql.Field{Type: graphql.String},
+				"categoryName": &graphql.Field{Type: graphql.String},
+				"productCode":  &graphql.Field{Type: graphql.String},
+				"pageCount":    &graphql.Field{Type: graphql.Int},
+				"releasedYear": &graphql.Field{Type: graphql.Int},
+				"author": &graphql.Field{
+					Type:        authorType,
+					Description: "Kitabın yazarı (author-service)",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveAuthor(state(p), p.Source.(*Book).AuthorName), nil
+					},
+				},
+				"genre": &graphql.Field{
+					Type:        genreType,
+					Description: "Kitabın türü (genre-service)",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveGenre(state(p), p.Source.(*Book).CategoryName), nil
+					},
+				},
+			}
+		}),
+	})
+
+	authorType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Author",
+		Description: "author-service yazar kaydı",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"name": &graphql.Field{Type: graphql.String},
+				"bookCount": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						load := state(p).authors.Load(p.Source.(*Author).Name)
+						return func() (interface{}, error) {
+							detail, err := load()
+							if detail == nil {
+								return nil, err
+							}
+							return detail.BookCount, nil
+						}, nil
+					},
+				},
+				"books": &graphql.Field{
+					Type: graphql.NewList(bookType),
+					Args: limitArg(10),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := listLimit(p.Args, "limit")
+						if err != nil {
+							return nil, err
+						}
+						load := state(p).authors.Load(p.Source.(*Author).Name)
+						return func() (interface{}, error) {
+							detail, err := load()
+							if detail == nil {
+								return nil, err
+							}
+							return firstBooks(detail.Books, limit), nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	genreType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Genre",
+		Description: "genre-service tür kaydı",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"name":        &graphql.Field{Type: graphql.String},
+				"description": &graphql.Field{Type: graphql.String},
+				"bookCount": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						load := state(p).genres.Load(p.Source.(*Genre).Name)
+						return func() (interface{}, error) {
+							detail, err := load()
+							if detail == nil {
+								return nil, err
+							}
+							return detail.BookCount, nil
+						}, nil
+					},
+				},
+				"books": &graphql.Field{
+					Type: graphql.NewList(bookType),
+					Args: limitArg(10),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := listLimit(p.Args, "limit")
+						if err != nil {
+							return nil, err
+						}
+						load := state(p).genres.Load(p.Source.(*Genre).Name)
+						return func() (interface{}, error) {
+							detail, err := load()
+							if detail == nil {
+								return nil, err
+							}
+							return firstBooks(detail.Books, limit), nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	recommendationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Recommendation",
+		Description: "recommendation-service öneri kaydı",
+		Fields: graphql.Fields{
+			"book":   &graphql.Field{Type: bookType},
+			"reason": &graphql.Field{Type: graphql.String},
+			"score":  &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	recommendationKind := graphql.NewEnum(graphql.EnumConfig{
+		Name: "RecommendationKind",
+		Values: graphql.EnumValueConfigMap{
+			"ALL":         &graphql.EnumValueConfig{Value: "/api/recommendations"},
+			"TRENDING":    &graphql.EnumValueConfig{Value: "/api/recommendations/trending"},
+			"BY_CATEGORY": &graphql.EnumValueConfig{Value: "/api/recommendations/by-category"},
+			"BY_AUTHOR":   &graphql.EnumValueConfig{Value: "/api/recommendations/by-author"},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "auth-service kullanıcı kaydı",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"username":  &graphql.Field{Type: graphql.String},
+			"email":     &graphql.Field{Type: graphql.String},
+			"createdAt": &graphql.Field{Type: graphql.String},
+			"updatedAt": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	pageType := func(name, listField string, itemType *graphql.Object) *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				listField:    &graphql.Field{Type: graphql.NewList(itemType)},
+				"total":      &graphql.Field{Type: graphql.Int},
+				"page":       &graphql.Field{Type: graphql.Int},
+				"pageSize":   &graphql.Field{Type: graphql.Int},
+				"totalPages": &graphql.Field{Type: graphql.Int},
+			},
+		})
+	}
+
+	bookPageArgs := graphql.FieldConfigArgument{
+		"category": &graphql.ArgumentConfig{Type: graphql.String},
+		"author":   &graphql.ArgumentConfig{Type: graphql.String},
+	}
+	for name, arg := range pageArgs {
+		bookPageArgs[name] = arg
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := state(p).books.Load(p.Args["id"].(int))
+					return func() (interface{}, error) { return load() }, nil
+				},
+			},
+			"books": &graphql.Field{
+				Type: pageType("BookPage", "books", bookType),
+				Args: bookPageArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					query, err := pageQuery(p.Args, "category", "author")
+					if err != nil {
+						return nil, err
+					}
+					st := state(p)
+					var page BookPage
+					if _, err := st.client.get(p.Context, "book-service", "/api/books?"+query.Encode(), &page); err != nil {
+						return nil, err
+					}
+					for _, book := range page.Books {
+						st.books.Prime(book.ID, book)
+					}
+					return &page, nil
+				},
+			},
+			"author": &graphql.Field{
+				Type: authorType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveAuthor(state(p), p.Args["name"].(string)), nil
+				},
+			},
+			"authors": &graphql.Field{
+				Type: pageType("AuthorPage", "authors", authorType),
+				Args: pageArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					query, err := pageQuery(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					var page AuthorPage
+					if _, err := state(p).client.get(p.Context, "author-service", "/api/authors?"+query.Encode(), &page); err != nil {
+						return nil, err
+					}
+					return &page, nil
+				},
+			},
+			"genre": &graphql.Field{
+				Type: genreType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveGenre(state(p), p.Args["name"].(string)), nil
+				},
+			},
+			"genres": &graphql.Field{
+				Type: pageType("GenrePage", "genres", genreType),
+				Args: pageArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					query, err := pageQuery(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					var page GenrePage
+					if _, err := state(p).client.get(p.Context, "genre-service", "/api/genres?"+query.Encode(), &page); err != nil {
+						return nil, err
+					}
+					return &page, nil
+				},
+			},
+			"recommendations": &graphql.Field{
+				Type: graphql.NewList(recommendationType),
+				Args: graphql.FieldConfigArgument{
+					"kind":     &graphql.ArgumentConfig{Type: recommendationKind, DefaultValue: "/api/recommendations"},
+					"limit":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					"category": &graphql.ArgumentConfig{Type: graphql.String},
+					"author":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, err := listLimit(p.Args, "limit")
+					if err != nil {
+						return nil, err
+					}
+					query := url.Values{}
+					query.Set("limit", strconv.Itoa(limit))
+					for _, name := range []string{"category", "author"} {
+						if value, ok := p.Args[name].(string); ok && value != "" {
+							query.Set(name, value)
+						}
+					}
+					var list recommendationList
+					path := p.Args["kind"].(string) + "?" + query.Encode()
+					if _, err := state(p).client.get(p.Context, "recommendation-service", path, &list); err != nil {
+						return nil, err
+					}
+					return list.Recommendations, nil
+				},
+			},
+			"me": &graphql.Field{
+				Type:        userType,
+				Description: "Token sahibi kullanıcı (Authorization header'ı gerekli)",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fetchUser(p, "/api/auth/profile")
+				},
+			},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "ID ile kullanıcı (Authorization header'ı gerekli)",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fetchUser(p, fmt.Sprintf("/api/auth/users/%d", p.Args["id"].(int)))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// resolveAuthor yazarı dataloader üzerinden getiren thunk döner
+func resolveAuthor(st *requestState, name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	load := st.authors.Load(name)
+	return func() (interface{}, error) {
+		detail, err := load()
+		if detail == nil {
+			return nil, err
+		}
+		return &detail.Author, nil
+	}
+}
+
+// resolveGenre türü dataloader üzerinden getiren thunk döner
+func resolveGenre(st *requestState, name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	load := st.genres.Load(name)
+	return func() (interface{}, error) {
+		detail, err := load()
+		if detail == nil {
+			return nil, err
+		}
+		return &detail.Genre, nil
+	}
+}
+
+// fetchUser auth-service'den kullanıcıyı istemcinin Authorization header'ıyla getirir
+func fetchUser(p graphql.ResolveParams, path string) (interface{}, error) {
+	var user User
+	found, err := state(p).client.getRaw(p.Context, "auth-service", path, &user)
+	if !found {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// pageQuery sayfalama ve arama argümanlarını servislerin query parametrelerine çevirir
+func pageQuery(args map[string]interface{}, filters ...string) (url.Values, error) {
+	pageSize, err := listLimit(args, "pageSize")
+	if err != nil {
+		return nil, err
+	}
+	page, _ := args["page"].(int)
+	if page < 1 {
+		return nil, fmt.Errorf("page en az 1 olmalı")
+	}
+
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	for _, name := range append([]string{"search"}, filters...) {
+		if value, ok := args[name].(string); ok && value != "" {
+			query.Set(name, value)
+		}
+	}
+	return query, nil
+}
+
+// listLimit liste boyutu argümanını 1-maxListLimit aralığında doğrular
+func listLimit(args map[string]interface{}, name string) (int, error) {
+	limit, _ := args[name].(int)
+	if limit < 1 || limit > maxListLimit {
+		return 0, fmt.Errorf("%s 1-%d arasında olmalı", name, maxListLimit)
+	}
+	return limit, nil
+}
+
+// firstBooks listenin ilk limit elemanını döner
+func firstBooks(books []*Book, limit int) []*Book {
+	if len(books) > limit {
+		return books[:limit]
+	}
+	return books
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler ayrı porttan sunulan yönetim API'sinin handler'ları; her değişiklik audit log'a yazılır
+type AdminHandler struct {
+	routeService  service.RouteService
+	loadBalancer  service.LoadBalancer
+	breakers      service.CircuitBreakerRegistry
+	cache         service.ResponseCache
+	healthChecker service.HealthChecker
+	audit         service.AuditLog
+	config        *configs.Config
+}
+
+// NewAdminHandler yeni yönetim handler'ı oluşturur
+func NewAdminHandler(routeService service.RouteService, loadBalancer service.LoadBalancer, breakers service.CircuitBreakerRegistry, cache service.ResponseCache, healthChecker service.HealthChecker, audit service.AuditLog, config *configs.Config) *AdminHandler {
+	return &AdminHandler{
+		routeService:  routeService,
+		loadBalancer:  loadBalancer,
+		breakers:      breakers,
+		cache:         cache,
+		healthChecker: healthChecker,
+		audit:         audit,
+		config:        config,
+	}
+}
+
+// upstreamState yönetim API'sinde bir upstream'in instance, health ve circuit durumu
+type upstreamState struct {
+	Instances []service.InstanceStatus `json:"instances"`
+	Health    *service.ServiceHealth   `json:"health,omitempty"`
+	Circuit   *service.CircuitStatus   `json:"circuit,omitempty"`
+}
+
+// routeLimits route'a uygulanan etkin timeout ve boyut sınırları (okunabilir süre formatında)
+type routeLimits struct {
+	ConnectTimeout        string `json:"connect_timeout"`
+	ResponseHeaderTimeout string `json:"response_header_timeout"`
+	Timeout               string `json:"timeout"`
+	IdleTimeout           string `json:"idle_timeout"`
+	MaxBodyBytes          int64  `json:"max_body_bytes"`
+}
+
+// instanceUpdate instance drain/ağırlık değişikliği isteği; verilmeyen alanlar değiştirilmez
+type instanceUpdate struct {
+	URL      string `json:"url"`
+	Draining *bool  `json:"draining"`
+	Weight   *int   `json:"weight"`
+}
+
+// logLevelUpdate log seviyesi değişikliği isteği
+type logLevelUpdate struct {
+	Level string `json:"level"`
+}
+
+// Routes aktif route tablosunu ve upstream limitlerini döner
+func (h *AdminHandler) Routes(c *gin.Context) {
+	routes := h.routeService.Routes()
+	limits := make(map[string]routeLimits, len(routes))
+	for _, route := range routes {
+		effective := route.Limits(h.config.Proxy)
+		limits[route.Prefix] = routeLimits{
+			ConnectTimeout:        effective.ConnectTimeout.String(),
+			ResponseHeaderTimeout: effective.ResponseHeaderTimeout.String(),
+			Timeout:               effective.Timeout.String(),
+			IdleTimeout:           effective.IdleTimeout.String(),
+			MaxBodyBytes:          effective.MaxBodyBytes,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"file":   h.config.Routing.File,
+			"routes": routes,
+			"limits": limits,
+		},
+	})
+}
+
+// ReloadRoutes route dosyasını beklemeden yeniden yükler
+func (h *AdminHandler) ReloadRoutes(c *gin.Context) {
+	before := len(h.routeService.Routes())
+	if err := h.routeService.Reload(); err != nil {
+		respondAdminError(c, http.StatusUnprocessableEntity, "INVALID_ROUTES", "Route tablosu yeniden yüklenemedi: "+err.Error())
+		return
+	}
+	after := len(h.routeService.Routes())
+
+	h.record(c, "routes.reload", h.config.Routing.File, before, after)
+	h.Routes(c)
+}
+
+// Upstreams upstream'lerin instance (ağırlık, drain, devam eden istek), health ve circuit durumlarını döner
+func (h *AdminHandler) Upstreams(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"upstreams": h.upstreams(),
+		},
+	})
+}
+
+// UpdateInstance instance'ı drain eder/tekrar trafiğe alır veya ağırlığını değiştirir
+func (h *AdminHandler) UpdateInstance(c *gin.Context) {
+	upstream := c.Param("upstream")
+
+	var req instanceUpdate
+	if err := c.ShouldBindJSON(&req); err != nil || req.URL == "" || (req.Draining == nil && req.Weight == nil) {
+		respondAdminError(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek: url ile birlikte draining veya weight verilmeli")
+		return
+	}
+
+	req.URL = strings.TrimSuffix(req.URL, "/")
+	target := upstream + " " + req.URL
+
+	// Önce tüm alanlar doğrulanır; biri geçersizse hiçbir değişiklik uygulanmaz
+	if req.Weight != nil && (*req.Weight < service.DefaultInstanceWeight || *req.Weight > service.MaxInstanceWeight) {
+		respondInstanceError(c, service.ErrInvalidWeight)
+		return
+	}
+	if !h.hasInstance(upstream, req.URL) {
+		respondInstanceError(c, fmt.Errorf("%s: %w", target, service.ErrUnknownInstance))
+		return
+	}
+
+	if req.Weight != nil {
+		previous, err := h.loadBalancer.SetWeight(upstream, req.URL, *req.Weight)
+		if err != nil {
+			respondInstanceError(c, err)
+			return
+		}
+		h.record(c, "instance.weight", target, previous, *req.Weight)
+	}
+	if req.Draining != nil {
+		previous, err := h.loadBalancer.SetDraining(upstream, req.URL, *req.Draining)
+		if err != nil {
+			respondInstanceError(c, err)
+			return
+		}
+		h.record(c, "instance.draining", target, previous, *req.Draining)
+	}
+
+	state := h.upstreams()[upstream]
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"upstream":  upstream,
+			"instances": state.Instances,
+		},
+	})
+}
+
+// hasInstance instance'ın upstream havuzunda olup olmadığını kontrol eder
+func (h *AdminHandler) hasInstance(upstream, url string) bool {
+	for _, instance := range h.loadBalancer.Status()[upstream] {
+		if instance.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheStats response cache istatistiklerini döner
+func (h *AdminHandler) CacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": h.cache.Stats(),
+	})
+}
+
+// PurgeCache response cache'ini temizler; prefix verilirse sadece o path altındaki yanıtlar silinir
+func (h *AdminHandler) PurgeCache(c *gin.Context) {
+	prefix := c.Query("prefix")
+	before := h.cache.Stats().Entries
+	purged := h.cache.Purge(prefix)
+
+	h.record(c, "cache.purge", "prefix="+prefix, before, before-purged)
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"purged": purged,
+			"prefix": prefix,
+			"stats":  h.cache.Stats(),
+		},
+	})
+}
+
+// LogLevel aktif log seviyesini döner
+func (h *AdminHandler) LogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"level": logging.CurrentLevel().String(),
+		},
+	})
+}
+
+// SetLogLevel log seviyesini yeniden başlatmadan değiştirir
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var req logLevelUpdate
+	if err := c.ShouldBindJSON(&req); err != nil || req.Level == "" {
+		respondAdminError(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek: level verilmeli")
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		respondAdminError(c, http.StatusBadRequest, "INVALID_LOG_LEVEL", err.Error())
+		return
+	}
+
+	previous := logging.SetLevel(level)
+	h.record(c, "log.level", "gateway", previous.String(), level.String())
+	h.LogLevel(c)
+}
+
+// AuditLog yönetim API'si üzerinden yapılan son değişiklikleri en yeniden eskiye döner
+func (h *AdminHandler) AuditLog(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": h.audit.Entries(),
+	})
+}
+
+// upstreams load balancer, health checker ve circuit breaker durumlarını upstream bazında birleştirir
+func (h *AdminHandler) upstreams() map[string]upstreamState {
+	report := h.healthChecker.Report()
+	circuits := h.breakers.Status()
+
+	result := make(map[string]upstreamState)
+	for name, instances := range h.loadBalancer.Status() {
+		sort.Slice(instances, func(i, j int) bool { return instances[i].URL < instances[j].URL })
+		state := upstreamState{Instances: instances}
+		if health, ok := report.Services[name]; ok {
+			state.Health = &health
+		}
+		if circuit, ok := circuits[name]; ok {
+			state.Circuit = &circuit
+		}
+		result[name] = state
+	}
+	return result
+}
+
+// record değişikliği isteği yapanın adresi ve istek ID'si ile audit log'a yazar
+func (h *AdminHandler) record(c *gin.Context, action, target string, before, after interface{}) {
+	h.audit.Record(service.AuditEntry{
+		RequestID: requestid.Get(c),
+		Actor:     c.ClientIP(),
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     after,
+	})
+}
+
+// respondInstanceError load balancer hatasını uygun HTTP yanıtına çevirir
+func respondInstanceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownInstance):
+		respondAdminError(c, http.StatusNotFound, "INSTANCE_NOT_FOUND", err.Error())
+	case errors.Is(err, service.ErrInvalidWeight):
+		respondAdminError(c, http.StatusBadRequest, "INVALID_WEIGHT", err.Error())
+	default:
+		respondAdminError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+// respondAdminError yönetim API'si hata yanıtını gateway'in standart hata formatında yazar
+func respondAdminError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"code":       code,
+			"message":    message,
+			"request_id": requestid.Get(c),
+		},
+	})
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
 	"gateway-service/configs"
+	"gateway-service/internal/middleware"
+	"gateway-service/internal/requestid"
 	"gateway-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -12,15 +16,25 @@ import (
 
 // GatewayHandler HTTP handler'ları
 type GatewayHandler struct {
-	proxyService service.ProxyService
-	config       *configs.Config
+	proxyService  service.ProxyService
+	routeService  service.RouteService
+	healthChecker service.HealthChecker
+	composite     service.CompositeService
+	splitter      service.TrafficSplitter
+	mirror        service.TrafficMirror
+	config        *configs.Config
 }
 
 // NewGatewayHandler yeni gateway handler oluşturur
-func NewGatewayHandler(proxyService service.ProxyService, config *configs.Config) *GatewayHandler {
+func NewGatewayHandler(proxyService service.ProxyService, routeService service.RouteService, healthChecker service.HealthChecker, composite service.CompositeService, splitter service.TrafficSplitter, mirror service.TrafficMirror, config *configs.Config) *GatewayHandler {
 	return &GatewayHandler{
-		proxyService: proxyService,
-		config:       config,
+		proxyService:  proxyService,
+		routeService:  routeService,
+		healthChecker: healthChecker,
+		composite:     composite,
+		splitter:      splitter,
+		mirror:        mirror,
+		config:        config,
 	}
 }
 
@@ -33,63 +47,105 @@ func (h *GatewayHandler) HealthCheck(c *gin.Context) {
 	})
 }
 
-// ServicesHealthCheck tüm servislerin health durumunu kontrol eder
+// ServicesHealthCheck arka planda toplanan servis health durumunu döner (upstream'lere istek atmaz).
+// Public endpoint olduğu için sadece servis bazında toplam durum döner; instance URL'leri,
+// health detayları ve circuit durumları yönetim API'sindeki /admin/upstreams altındadır.
 func (h *GatewayHandler) ServicesHealthCheck(c *gin.Context) {
-	services := map[string]string{
-		"book-service":          h.config.Services.BookServiceURL,
-		"author-service":        h.config.Services.AuthorServiceURL,
-		"genre-service":         h.config.Services.GenreServiceURL,
-		"recommendation-service": h.config.Services.RecommendationServiceURL,
-		"auth-service":          h.config.Services.AuthServiceURL,
+	report := h.healthChecker.Report()
+
+	c.JSON(http.StatusOK, gin.H{
+		"gateway":    "OK",
+		"services":   report.Summary(),
+		"checked_at": report.CheckedAt,
+	})
+}
+
+// CompositeBookDetail kitap, yazar, tür ve önerileri tek yanıtta birleştiren endpoint.
+// Yazar, tür veya öneri servisi yanıt vermezse ilgili bölüm null döner ve sections altında işaretlenir.
+func (h *GatewayHandler) CompositeBookDetail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":       "INVALID_ID",
+				"message":    "Geçersiz ID formatı",
+				"request_id": requestid.Get(c),
+			},
+		})
+		return
 	}
 
-	serviceHealths := h.proxyService.CheckAllServicesHealth(services)
+	detail, err := h.composite.BookDetail(c.Request.Context(), id, c.Request.Header)
+	if err != nil {
+		status, code, message := http.StatusBadGateway, "COMPOSITE_UNAVAILABLE", "Kitap bilgisi alınamadı"
+		if errors.Is(err, service.ErrCompositeNotFound) {
+			status, code, message = http.StatusNotFound, "BOOK_NOT_FOUND", "Kitap bulunamadı"
+		}
+		c.JSON(status, gin.H{
+			"error": gin.H{
+				"code":       code,
+				"message":    message,
+				"request_id": requestid.Get(c),
+				"sections":   detail.Sections,
+			},
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"gateway":  "OK",
-		"services": serviceHealths,
+		"data": detail,
 	})
 }
 
-// RouteToService dinamik olarak istekleri doğru servise yönlendirir
+// RouteToService istekleri route tablosuna göre ilgili servise yönlendirir
 func (h *GatewayHandler) RouteToService(c *gin.Context) {
-	// URL path'ini analiz et
 	path := c.Request.URL.Path
-	
-	// Service'i path'e göre belirle
-	var targetURL string
-	var serviceName string
-	
-	switch {
-	case strings.HasPrefix(path, "/api/books"):
-		targetURL = h.config.Services.BookServiceURL
-		serviceName = "book-service"
-	case strings.HasPrefix(path, "/api/authors"):
-		targetURL = h.config.Services.AuthorServiceURL
-		serviceName = "author-service"
-	case strings.HasPrefix(path, "/api/genres"):
-		targetURL = h.config.Services.GenreServiceURL
-		serviceName = "genre-service"
-	case strings.HasPrefix(path, "/api/recommendations"):
-		targetURL = h.config.Services.RecommendationServiceURL
-		serviceName = "recommendation-service"
-	case strings.HasPrefix(path, "/api/auth"):
-		targetURL = h.config.Services.AuthServiceURL
-		serviceName = "auth-service"
-	default:
+
+	route, ok := h.routeService.Match(path)
+	if !ok {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": gin.H{
-				"code":    "SERVICE_NOT_FOUND",
-				"message": "İlgili servis bulunamadı",
-				"path":    path,
+				"code":       "SERVICE_NOT_FOUND",
+				"message":    "İlgili servis bulunamadı",
+				"request_id": requestid.Get(c),
+				"path":       path,
+			},
+		})
+		return
+	}
+
+	if !route.AllowsMethod(c.Request.Method) {
+		c.JSON(http.StatusMethodNotAllowed, gin.H{
+			"error": gin.H{
+				"code":       "METHOD_NOT_ALLOWED",
+				"message":    "Bu route için HTTP metodu desteklenmiyor",
+				"request_id": requestid.Get(c),
+				"path":       path,
+				"method":     c.Request.Method,
 			},
 		})
 		return
 	}
 
+	// API versiyonu ve deprecated versiyonlar için Deprecation/Sunset header'ları
+	if version := route.APIVersion(); version != nil {
+		for key, values := range version.ResponseHeaders() {
+			c.Writer.Header()[key] = values
+		}
+	}
+
+	// Canary/ağırlıklı trafik bölme - route kopyasının upstream'i seçilen versiyonunkiyle değiştirilir
+	if version := h.splitter.Select(c, route, c.GetString(middleware.ContextUserID)); version != nil {
+		route.Upstream = version.Upstream
+		c.Header(configs.HeaderUpstreamVersion, version.Name)
+		defer h.splitter.Observe(c, route, version, time.Now())
+	}
+
+	// Shadow trafik - örneklenen GET istekleri gölge upstream'e de gönderilir, gölge yanıt istemciye dönmez
+	if session := h.mirror.Mirror(c, route); session != nil {
+		defer session.Finish(c)
+	}
+
 	// İsteği ilgili servise yönlendir
-	h.proxyService.ProxyRequest(c, targetURL, serviceName)
+	h.proxyService.ProxyRequest(c, route)
 }
-
-// Eski metodları kaldırıyoruz, artık RouteToService kullanacağız
-// ProxyToBookService, ProxyToAuthorService, vs. metodları gereksiz 
\ No newline at end of file
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gateway-service/internal/graph"
+	"gateway-service/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxGraphQLBodyBytes GraphQL istek gövdesi için üst sınır
+const maxGraphQLBodyBytes = 1 << 20
+
+// GraphQLHandler gateway GraphQL endpoint'i
+type GraphQLHandler struct {
+	executor *graph.Executor
+}
+
+// NewGraphQLHandler yeni GraphQL handler oluşturur
+func NewGraphQLHandler(executor *graph.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+// Serve GET (?query=&variables=) ve POST (JSON gövde) ile gelen GraphQL sorgularını çalıştırır.
+// Parse, doğrulama veya limit hatalarında 400, diğer durumlarda alan hataları errors altında olacak şekilde 200 döner.
+func (h *GraphQLHandler) Serve(c *gin.Context) {
+	req, err := h.parseRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":       "INVALID_GRAPHQL_REQUEST",
+				"message":    err.Error(),
+				"request_id": requestid.Get(c),
+			},
+		})
+		return
+	}
+
+	result, err := h.executor.Execute(c.Request.Context(), req, c.Request.Header)
+	if errors.Is(err, graph.ErrInvalidQuery) {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseRequest istek metoduna göre sorguyu, operasyon adını ve değişkenleri okur
+func (h *GraphQLHandler) parseRequest(c *gin.Context) (graph.Request, error) {
+	var req graph.Request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, errors.New("variables geçerli bir JSON nesnesi olmalı")
+			}
+		}
+	} else {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLBodyBytes)
+		if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+			return req, errors.New("istek gövdesi geçerli bir GraphQL JSON nesnesi olmalı")
+		}
+	}
+
+	if req.Query == "" {
+		return req, errors.New("query alanı gerekli")
+	}
+	return req, nil
+}
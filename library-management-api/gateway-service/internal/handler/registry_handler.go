@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"gateway-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegistryHandler servislerin kendini kaydettiği ve diğer servisleri isimle bulduğu service registry handler'ları
+type RegistryHandler struct {
+	registry     service.ServiceRegistry
+	loadBalancer service.LoadBalancer
+}
+
+// NewRegistryHandler yeni registry handler'ı oluşturur
+func NewRegistryHandler(registry service.ServiceRegistry, loadBalancer service.LoadBalancer) *RegistryHandler {
+	return &RegistryHandler{
+		registry:     registry,
+		loadBalancer: loadBalancer,
+	}
+}
+
+// registration instance kayıt isteği
+type registration struct {
+	Service string `json:"service"`
+	URL     string `json:"url"`
+}
+
+// discoveredInstance kayıtlı instance ve gateway health checker'ının gördüğü durumu
+type discoveredInstance struct {
+	service.RegisteredInstance
+	Healthy  bool `json:"healthy"`
+	Draining bool `json:"draining"`
+}
+
+// Register instance'ı kaydeder; yanıttaki ttl içinde heartbeat gönderilmelidir
+func (h *RegistryHandler) Register(c *gin.Context) {
+	var req registration
+	if err := c.ShouldBindJSON(&req); err != nil || req.Service == "" || req.URL == "" {
+		respondAdminError(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek: service ve url verilmeli")
+		return
+	}
+
+	instance, err := h.registry.Register(req.Service, req.URL)
+	if err != nil {
+		respondRegistryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": gin.H{
+			"instance": instance,
+			"ttl":      h.registry.TTL().String(),
+		},
+	})
+}
+
+// Heartbeat kaydın süresini uzatır; 404 dönerse servis yeniden kaydolmalıdır
+func (h *RegistryHandler) Heartbeat(c *gin.Context) {
+	instance, err := h.registry.Heartbeat(c.Param("id"))
+	if err != nil {
+		respondRegistryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"instance": instance,
+			"ttl":      h.registry.TTL().String(),
+		},
+	})
+}
+
+// Deregister kapanan instance'ın kaydını siler
+func (h *RegistryHandler) Deregister(c *gin.Context) {
+	instance, err := h.registry.Deregister(c.Param("id"))
+	if err != nil {
+		respondRegistryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"instance": instance,
+		},
+	})
+}
+
+// Services tüm servislerin kayıtlı instance'larını health durumlarıyla döner
+func (h *RegistryHandler) Services(c *gin.Context) {
+	status := h.loadBalancer.Status()
+	result := make(map[string][]discoveredInstance)
+	for name, instances := range h.registry.Services() {
+		result[name] = discovered(instances, status[name])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// Service servisin kayıtlı instance'larını health durumlarıyla döner; kayıt yoksa liste boştur
+// ve istemciler statik konfigürasyondaki URL'i kullanır
+func (h *RegistryHandler) Service(c *gin.Context) {
+	name := c.Param("name")
+	services := h.registry.Services()
+	instances, ok := services[name]
+	if !ok {
+		respondAdminError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", "Tanımsız servis: "+name)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"service":   name,
+			"instances": discovered(instances, h.loadBalancer.Status()[name]),
+		},
+	})
+}
+
+// discovered kayıtlı instance'ları load balancer'daki health ve drain durumlarıyla birleştirir.
+// Henüz health check'ten geçmemiş yeni instance'lar load balancer'da sağlıklı başladığı için sağlıklı görünür.
+func discovered(instances []service.RegisteredInstance, status []service.InstanceStatus) []discoveredInstance {
+	byURL := make(map[string]service.InstanceStatus, len(status))
+	for _, instance := range status {
+		byURL[instance.URL] = instance
+	}
+
+	result := make([]discoveredInstance, 0, len(instances))
+	for _, instance := range instances {
+		state, ok := byURL[instance.URL]
+		result = append(result, discoveredInstance{
+			RegisteredInstance: instance,
+			Healthy:            !ok || state.Healthy,
+			Draining:           ok && state.Draining,
+		})
+	}
+	return result
+}
+
+// respondRegistryError registry hatasını uygun HTTP yanıtına çevirir
+func respondRegistryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownService):
+		respondAdminError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case errors.Is(err, service.ErrInstanceNotRegistered):
+		respondAdminError(c, http.StatusNotFound, "INSTANCE_NOT_REGISTERED", err.Error())
+	case errors.Is(err, service.ErrInvalidInstanceURL):
+		respondAdminError(c, http.StatusBadRequest, "INVALID_INSTANCE_URL", err.Error())
+	default:
+		respondAdminError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// Level log seviyesi; seçilen seviyenin altındaki log satırları yazılmaz
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// current çalışma anında değiştirilebilen aktif log seviyesi
+var current atomic.Int32
+
+func init() {
+	current.Store(int32(LevelInfo))
+}
+
+// String seviyenin adını döner
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+}
+
+// ParseLevel seviye adını (debug, info, warn, error) çözer
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("geçersiz log seviyesi: %q (debug, info, warn, error)", name)
+	}
+}
+
+// SetLevel aktif log seviyesini değiştirir ve önceki seviyeyi döner
+func SetLevel(level Level) Level {
+	return Level(current.Swap(int32(level)))
+}
+
+// CurrentLevel aktif log seviyesini döner
+func CurrentLevel() Level {
+	return Level(current.Load())
+}
+
+// Enabled verilen seviyedeki log satırlarının yazılıp yazılmayacağını döner
+func Enabled(level Level) bool {
+	return level >= CurrentLevel()
+}
+
+// Logf seviye aktifse log satırını yazar
+func Logf(level Level, format string, args ...interface{}) {
+	if Enabled(level) {
+		log.Printf(format, args...)
+	}
+}
+
+// Debugf debug seviyesinde log yazar
+func Debugf(format string, args ...interface{}) {
+	Logf(LevelDebug, format, args...)
+}
+
+// Infof info seviyesinde log yazar
+func Infof(format string, args ...interface{}) {
+	Logf(LevelInfo, format, args...)
+}
+
+// Warnf warn seviyesinde log yazar
+func Warnf(format string, args ...interface{}) {
+	Logf(LevelWarn, format, args...)
+}
+
+// Errorf error seviyesinde log yazar
+func Errorf(format string, args ...interface{}) {
+	Logf(LevelError, format, args...)
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute hiçbir route'a uymayan istekler için kullanılan etiket (cardinality'yi sınırlar)
+const unmatchedRoute = "unmatched"
+
+// Metrics servisin Prometheus registry'si ve HTTP metrikleri
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// New servis adıyla etiketlenmiş HTTP metriklerini ve Go runtime/process collector'larını oluşturur
+func New(service string) *Metrics {
+	labels := prometheus.Labels{"service": service}
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "http_requests_total",
+			Help:        "İşlenen HTTP isteği sayısı",
+			ConstLabels: labels,
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "http_request_duration_seconds",
+			Help:        "HTTP istek süreleri",
+			ConstLabels: labels,
+			Buckets:     prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "http_requests_in_flight",
+			Help:        "Devam eden HTTP isteği sayısı",
+			ConstLabels: labels,
+		}, []string{"method", "route"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.inFlight,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Register ek collector'ları (ör. upstream durum metrikleri) registry'ye ekler
+func (m *Metrics) Register(collectors ...prometheus.Collector) {
+	m.registry.MustRegister(collectors...)
+}
+
+// Middleware her isteği route, metod ve durum koduna göre ölçer.
+// routeLabel isteğin etiketini belirler (dinamik route'larda gin şablonu yerine route prefix'i kullanılır).
+func (m *Metrics) Middleware(routeLabel func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := routeLabel(c)
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+
+		inFlight := m.inFlight.WithLabelValues(method, route)
+		inFlight.Inc()
+		start := time.Now()
+
+		c.Next()
+
+		inFlight.Dec()
+		status := strconv.Itoa(c.Writer.Status())
+		m.requests.WithLabelValues(method, route, status).Inc()
+		m.duration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler metrikleri Prometheus formatında sunan handler
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
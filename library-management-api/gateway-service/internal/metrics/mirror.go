@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MirrorMetrics shadow trafik kopyalama metrikleri
+type MirrorMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMirrorMetrics shadow metriklerini oluşturur ve registry'ye ekler
+func NewMirrorMetrics(m *Metrics) *MirrorMetrics {
+	s := &MirrorMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gateway_mirror_requests_total",
+			Help: "Gölge upstream'e kopyalanan isteklerin karşılaştırma sonuçları",
+		}, []string{"route", "shadow_upstream", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gateway_mirror_shadow_duration_seconds",
+			Help:    "Gölge upstream isteklerinin süreleri",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "shadow_upstream"}),
+	}
+
+	m.Register(s.requests, s.duration)
+	return s
+}
+
+// Result kopyalanan isteğin karşılaştırma sonucunu sayar
+func (s *MirrorMetrics) Result(route, shadowUpstream, result string) {
+	s.requests.WithLabelValues(route, shadowUpstream, result).Inc()
+}
+
+// ObserveShadow gölge isteğin süresini kaydeder
+func (s *MirrorMetrics) ObserveShadow(route, shadowUpstream string, elapsed time.Duration) {
+	s.duration.WithLabelValues(route, shadowUpstream).Observe(elapsed.Seconds())
+}
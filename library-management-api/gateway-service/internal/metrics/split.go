@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SplitMetrics traffic split kullanılan route'larda versiyon bazında istek metrikleri;
+// canary ile stable sürümün hata oranı ve gecikmeleri karşılaştırılır
+type SplitMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewSplitMetrics versiyon metriklerini oluşturur ve registry'ye ekler
+func NewSplitMetrics(m *Metrics) *SplitMetrics {
+	s := &SplitMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gateway_route_version_requests_total",
+			Help: "Traffic split route'larında versiyona yönlendirilen istekler",
+		}, []string{"route", "version", "upstream", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gateway_route_version_request_duration_seconds",
+			Help:    "Traffic split route'larında versiyon bazında istek süreleri",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "version", "upstream"}),
+	}
+
+	m.Register(s.requests, s.duration)
+	return s
+}
+
+// Observe versiyona yönlendirilen isteğin durum kodunu ve süresini kaydeder
+func (s *SplitMetrics) Observe(route, version, upstream string, status int, elapsed time.Duration) {
+	s.requests.WithLabelValues(route, version, upstream, strconv.Itoa(status)).Inc()
+	s.duration.WithLabelValues(route, version, upstream).Observe(elapsed.Seconds())
+}
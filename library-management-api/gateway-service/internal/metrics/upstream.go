@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Upstream hata nedenleri
+const (
+	ReasonCircuitOpen = "circuit_open"
+	ReasonNoInstance  = "no_instance"
+	ReasonTimeout     = "timeout"
+	ReasonConnection  = "connection"
+	ReasonCanceled    = "canceled"
+	ReasonRequest     = "request"
+)
+
+// UpstreamMetrics gateway'in upstream servislere yaptığı çağrıların metrikleri
+type UpstreamMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+// NewUpstreamMetrics upstream metriklerini oluşturur ve registry'ye ekler
+func NewUpstreamMetrics(m *Metrics) *UpstreamMetrics {
+	u := &UpstreamMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gateway_upstream_request_duration_seconds",
+			Help:    "Upstream denemelerinin yanıt header'ları gelene kadar geçen süre",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"upstream", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gateway_upstream_errors_total",
+			Help: "İstemciye hata olarak dönen upstream çağrıları",
+		}, []string{"upstream", "reason"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gateway_upstream_retries_total",
+			Help: "Upstream'e yapılan tekrar denemeleri",
+		}, []string{"upstream"}),
+	}
+
+	m.Register(u.duration, u.errors, u.retries)
+	return u
+}
+
+// ObserveAttempt tek bir upstream denemesinin süresini kaydeder (status bağlantı hatalarında "error")
+func (u *UpstreamMetrics) ObserveAttempt(upstream, status string, elapsed time.Duration) {
+	u.duration.WithLabelValues(upstream, status).Observe(elapsed.Seconds())
+}
+
+// Error istemciye dönen upstream hatasını nedeniyle birlikte sayar
+func (u *UpstreamMetrics) Error(upstream, reason string) {
+	u.errors.WithLabelValues(upstream, reason).Inc()
+}
+
+// Retry upstream tekrar denemesini sayar
+func (u *UpstreamMetrics) Retry(upstream string) {
+	u.retries.WithLabelValues(upstream).Inc()
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"gateway-service/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminTokenHeader yönetim endpoint'leri için token header'ı
+const AdminTokenHeader = "X-Admin-Token"
+
+// RegistryTokenHeader service registry endpoint'leri için token header'ı
+const RegistryTokenHeader = "X-Registry-Token"
+
+// RequireAdminToken yönetim endpoint'lerini ADMIN_TOKEN ile korur; token tanımlı değilse endpoint'ler kapalıdır
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return requireToken(AdminTokenHeader, token, "ADMIN_DISABLED",
+		"Yönetim endpoint'leri devre dışı (ADMIN_TOKEN tanımlı değil)", "Geçersiz yönetim token'ı")
+}
+
+// RequireRegistryToken service registry endpoint'lerini REGISTRY_TOKEN ile korur; token tanımlı değilse registry kapalıdır
+func RequireRegistryToken(token string) gin.HandlerFunc {
+	return requireToken(RegistryTokenHeader, token, "REGISTRY_DISABLED",
+		"Service registry devre dışı (REGISTRY_TOKEN tanımlı değil)", "Geçersiz registry token'ı")
+}
+
+// requireToken header'daki token'ı sabit zamanlı karşılaştırma ile doğrulayan middleware'i oluşturur
+func requireToken(header, token, disabledCode, disabledMessage, invalidMessage string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":       disabledCode,
+					"message":    disabledMessage,
+					"request_id": requestid.Get(c),
+				},
+			})
+			return
+		}
+
+		provided := c.GetHeader(header)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":       "UNAUTHORIZED",
+					"message":    invalidMessage,
+					"request_id": requestid.Get(c),
+				},
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
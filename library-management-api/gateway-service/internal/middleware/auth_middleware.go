@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/service"
+	"gateway-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware gateway kenarında JWT doğrulaması yapan middleware
+type AuthMiddleware struct {
+	verifier     *utils.JWTVerifier
+	routeService service.RouteService
+}
+
+// NewAuthMiddleware yeni auth middleware oluşturur
+func NewAuthMiddleware(verifier *utils.JWTVerifier, routeService service.RouteService) *AuthMiddleware {
+	return &AuthMiddleware{
+		verifier:     verifier,
+		routeService: routeService,
+	}
+}
+
+// Authenticate route'un auth politikasına göre token'ı doğrular.
+// İstemcinin gönderdiği kimlik header'ları her zaman silinir, doğrulanmış kimlik upstream'e header olarak eklenir.
+func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, header := range IdentityHeaders {
+			c.Request.Header.Del(header)
+		}
+
+		route, ok := m.routeService.Match(c.Request.URL.Path)
+		if !ok || route.AuthPolicy() == configs.AuthNone {
+			c.Next()
+			return
+		}
+
+		// Preflight istekleri token taşımaz
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		identity, err := m.identify(c)
+		if err != nil {
+			if route.AuthPolicy() == configs.AuthOptional {
+				// Opsiyonel route'larda geçersiz token anonim istek olarak devam eder
+				c.Next()
+				return
+			}
+			requestid.Warnf(c.Request.Context(), "🔒 [%s] Yetkisiz istek: %s %s (%v)", route.Upstream, c.Request.Method, c.Request.URL.Path, err)
+			m.respondUnauthorized(c, err.Error())
+			return
+		}
+
+		if len(route.Roles) > 0 && !identity.HasAnyRole(route.Roles) {
+			requestid.Warnf(c.Request.Context(), "⛔ [%s] Yetersiz rol: kullanıcı %s, gerekli %v", route.Upstream, identity.Username, route.Roles)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":       "FORBIDDEN",
+					"message":    "Bu kaynağa erişim yetkiniz yok",
+					"request_id": requestid.Get(c),
+				},
+			})
+			return
+		}
+
+		m.setIdentity(c, identity)
+		c.Next()
+	}
+}
+
+// RequireIdentity route tablosu dışındaki ve birden fazla servise erişen endpoint'ler (composite, GraphQL) için
+// geçerli token zorunlu kılar. Bu endpoint'ler auth gerektiren route'ların verisini döndüğü için token'sız istekler 401 alır.
+func (m *AuthMiddleware) RequireIdentity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, err := m.identify(c)
+		if err != nil {
+			requestid.Warnf(c.Request.Context(), "🔒 [gateway] Yetkisiz istek: %s %s (%v)", c.Request.Method, c.Request.URL.Path, err)
+			m.respondUnauthorized(c, err.Error())
+			return
+		}
+
+		m.setIdentity(c, identity)
+		c.Next()
+	}
+}
+
+// setIdentity doğrulanmış kimliği context'e ve upstream header'larına ekler
+func (m *AuthMiddleware) setIdentity(c *gin.Context, identity *utils.Identity) {
+	c.Set(ContextUserID, identity.UserID)
+	c.Set(ContextUsername, identity.Username)
+	c.Set(ContextUserRoles, identity.Roles)
+
+	c.Request.Header.Set(HeaderUserID, identity.UserID)
+	c.Request.Header.Set(HeaderUsername, identity.Username)
+	if identity.Email != "" {
+		c.Request.Header.Set(HeaderUserEmail, identity.Email)
+	}
+	if len(identity.Roles) > 0 {
+		c.Request.Header.Set(HeaderUserRoles, strings.Join(identity.Roles, ","))
+	}
+}
+
+// identify Authorization header'ındaki token'ı doğrular
+func (m *AuthMiddleware) identify(c *gin.Context) (*utils.Identity, error) {
+	token, err := utils.ExtractTokenFromHeader(c.GetHeader("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+	return m.verifier.VerifyToken(token)
+}
+
+// respondUnauthorized 401 hata yanıtı gönderir
+func (m *AuthMiddleware) respondUnauthorized(c *gin.Context, details string) {
+	c.Header("WWW-Authenticate", `Bearer realm="library-gateway"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": gin.H{
+			"code":       "UNAUTHORIZED",
+			"message":    "Geçersiz veya eksik kimlik doğrulama token'ı",
+			"request_id": requestid.Get(c),
+			"details":    details,
+		},
+	})
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/service"
+	"gateway-service/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+// staticRoutes her path için aynı route'u dönen RouteService
+type staticRoutes struct {
+	service.RouteService
+	route configs.RouteConfig
+}
+
+func (s staticRoutes) Match(path string) (*configs.RouteConfig, bool) {
+	route := s.route
+	return &route, true
+}
+
+func testToken(t *testing.T, secret string, roles string, expiresIn time.Duration) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":  float64(7),
+		"username": "mert",
+		"roles":    roles,
+		"exp":      time.Now().Add(expiresIn).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("token imzalanamadı: %v", err)
+	}
+	return token
+}
+
+func TestAuthMiddlewareAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	valid := testToken(t, testSecret, "member", time.Hour)
+
+	tests := []struct {
+		name       string
+		auth       string
+		roles      []string
+		method     string
+		token      string
+		wantStatus int
+		wantUserID string
+	}{
+		{name: "auth gerektirmeyen route", auth: configs.AuthNone, wantStatus: http.StatusOK},
+		{name: "token'sız zorunlu route", auth: configs.AuthRequired, wantStatus: http.StatusUnauthorized},
+		{name: "geçerli token", auth: configs.AuthRequired, token: valid, wantStatus: http.StatusOK, wantUserID: "7"},
+		{name: "süresi dolmuş token", auth: configs.AuthRequired, token: testToken(t, testSecret, "", -time.Minute), wantStatus: http.StatusUnauthorized},
+		{name: "yanlış secret", auth: configs.AuthRequired, token: testToken(t, "other", "", time.Hour), wantStatus: http.StatusUnauthorized},
+		{name: "opsiyonel route geçersiz token ile anonim geçer", auth: configs.AuthOptional, token: "bozuk", wantStatus: http.StatusOK},
+		{name: "opsiyonel route geçerli token", auth: configs.AuthOptional, token: valid, wantStatus: http.StatusOK, wantUserID: "7"},
+		{name: "yetersiz rol", auth: configs.AuthRequired, roles: []string{"admin"}, token: valid, wantStatus: http.StatusForbidden},
+		{name: "preflight token istemez", auth: configs.AuthRequired, method: http.MethodOptions, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := staticRoutes{route: configs.RouteConfig{Prefix: "/api/books", Upstream: "book-service", Auth: tt.auth, Roles: tt.roles}}
+			auth := NewAuthMiddleware(utils.NewJWTVerifier(testSecret), routes)
+
+			var upstream http.Header
+			r := gin.New()
+			r.Use(auth.Authenticate())
+			r.Handle(http.MethodGet, "/api/books", func(c *gin.Context) { upstream = c.Request.Header.Clone() })
+			r.Handle(http.MethodOptions, "/api/books", func(c *gin.Context) { upstream = c.Request.Header.Clone() })
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request := httptest.NewRequest(method, "/api/books", nil)
+			// İstemcinin uydurduğu kimlik header'ları upstream'e hiçbir durumda ulaşmamalı
+			request.Header.Set(HeaderUserID, "1")
+			request.Header.Set(HeaderUserRoles, "admin")
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("durum = %d, beklenen %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if tt.wantStatus == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401 yanıtında WWW-Authenticate yok")
+				}
+				return
+			}
+			if got := upstream.Get(HeaderUserID); got != tt.wantUserID {
+				t.Errorf("upstream %s = %q, beklenen %q", HeaderUserID, got, tt.wantUserID)
+			}
+			if got := upstream.Get(HeaderUserRoles); tt.wantUserID == "" && got != "" {
+				t.Errorf("istemcinin gönderdiği %s silinmedi: %q", HeaderUserRoles, got)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRequireIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{name: "token'sız", wantStatus: http.StatusUnauthorized},
+		{name: "geçersiz token", token: testToken(t, "other", "", time.Hour), wantStatus: http.StatusUnauthorized},
+		{name: "geçerli token", token: testToken(t, testSecret, "member", time.Hour), wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Route tablosu dışındaki endpoint'lerde route politikası "none" olsa bile token zorunludur
+			routes := staticRoutes{route: configs.RouteConfig{Auth: configs.AuthNone}}
+			auth := NewAuthMiddleware(utils.NewJWTVerifier(testSecret), routes)
+
+			var userID string
+			r := gin.New()
+			r.GET("/api/composite/books/:id", auth.RequireIdentity(), func(c *gin.Context) {
+				userID = c.Request.Header.Get(HeaderUserID)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/composite/books/1", nil)
+			req.Header.Set(HeaderUserID, "1")
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, beklenen %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && userID != "7" {
+				t.Errorf("%s = %q, beklenen doğrulanmış kimlik", HeaderUserID, userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uncachedResponseHeaders cache'lenen yanıta dahil edilmeyen, isteğe özel header'lar.
+// X-Request-ID HIT yanıtında ilk isteğin ID'si yerine mevcut isteğin ID'si kalsın diye saklanmaz.
+var uncachedResponseHeaders = []string{
+	requestid.Header,
+	"Set-Cookie",
+	"Trailer",
+	"X-Retry-Count",
+	"X-Cache",
+	"Retry-After",
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+}
+
+// CacheMiddleware cache'i açık route'larda GET yanıtlarını gateway'de cache'ler.
+// Cache'lenen yanıtlar kullanıcılar arasında paylaşılır; auth kontrolü cache'ten önce yapılır.
+type CacheMiddleware struct {
+	cache         service.ResponseCache
+	routeService  service.RouteService
+	maxEntryBytes int64
+}
+
+// NewCacheMiddleware yeni cache middleware oluşturur
+func NewCacheMiddleware(cache service.ResponseCache, routeService service.RouteService, config configs.CacheConfig) *CacheMiddleware {
+	return &CacheMiddleware{
+		cache:         cache,
+		routeService:  routeService,
+		maxEntryBytes: config.MaxEntryBytes,
+	}
+}
+
+// Cache cache'ten yanıt döner veya upstream yanıtını cache'e yazar
+func (m *CacheMiddleware) Cache() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || service.IsUpgradeRequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		route, ok := m.routeService.Match(c.Request.URL.Path)
+		if !ok || !route.CacheEnabled() {
+			c.Next()
+			return
+		}
+
+		requestDirectives := parseCacheControl(c.Request.Header.Get("Cache-Control"))
+		if !requestDirectives.has("no-cache") && !requestDirectives.has("no-store") {
+			if cached, ok := m.cache.Get(c.Request); ok {
+				m.serveCached(c, cached)
+				return
+			}
+		}
+
+		writer := &cachingWriter{ResponseWriter: c.Writer, limit: m.maxEntryBytes}
+		c.Writer = writer
+		c.Header("X-Cache", "MISS")
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if requestDirectives.has("no-store") || writer.overflow {
+			return
+		}
+		// Aktarımı yarıda kalan (proxy body kopyalama hatasında isteği abort eder) yanıtlar cache'lenmez
+		if c.IsAborted() || len(c.Errors) > 0 {
+			return
+		}
+		m.store(c, route, writer)
+	}
+}
+
+// serveCached cache'teki yanıtı istemciye gönderir, ETag eşleşirse 304 döner
+func (m *CacheMiddleware) serveCached(c *gin.Context, cached *service.CachedResponse) {
+	header := c.Writer.Header()
+	for key, values := range cached.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	header.Set("Age", strconv.Itoa(int(time.Since(cached.StoredAt).Seconds())))
+	header.Set("X-Cache", "HIT")
+
+	if etag := cached.Header.Get("ETag"); etag != "" && etagMatches(c.GetHeader("If-None-Match"), etag) {
+		header.Del("Content-Length")
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(cached.Body)))
+	c.Status(cached.Status)
+	c.Writer.Write(cached.Body)
+	c.Abort()
+}
+
+// store yanıt cache'lenebilirse cache'e ekler
+func (m *CacheMiddleware) store(c *gin.Context, route *configs.RouteConfig, writer *cachingWriter) {
+	if c.Writer.Status() != http.StatusOK {
+		return
+	}
+
+	header := c.Writer.Header()
+	if strings.HasPrefix(header.Get("Content-Type"), "text/event-stream") {
+		return
+	}
+	// Yakalanan body upstream'in bildirdiği uzunlukta değilse yanıt eksik aktarılmıştır
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length != writer.body.Len() {
+		return
+	}
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if directives.has("no-store") || directives.has("private") {
+		return
+	}
+
+	vary := parseVary(header.Values("Vary"))
+	for _, name := range vary {
+		if name == "*" {
+			return
+		}
+	}
+
+	ttl := route.CacheTTL()
+	if ttl <= 0 {
+		ttl = upstreamTTL(directives, header)
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	cachedHeader := header.Clone()
+	for _, name := range uncachedResponseHeaders {
+		cachedHeader.Del(name)
+	}
+
+	now := time.Now()
+	m.cache.Set(c.Request, vary, &service.CachedResponse{
+		Path:      c.Request.URL.Path,
+		Status:    http.StatusOK,
+		Header:    cachedHeader,
+		Body:      writer.body.Bytes(),
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// cachingWriter yanıtı istemciye yazarken limit dahilinde kopyasını da tutar
+type cachingWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+// Write yanıtı istemciye yazar ve cache için kopyalar
+func (w *cachingWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString yanıtı istemciye yazar ve cache için kopyalar
+func (w *cachingWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// capture limit aşılmadıysa veriyi buffer'a ekler
+func (w *cachingWriter) capture(data []byte) {
+	if w.overflow {
+		return
+	}
+	if int64(w.body.Len()+len(data)) > w.limit {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
+
+// cacheDirectives Cache-Control direktifleri
+type cacheDirectives map[string]string
+
+// has direktifin var olup olmadığını kontrol eder
+func (d cacheDirectives) has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
+
+// seconds direktifin saniye değerini okur
+func (d cacheDirectives) seconds(name string) (time.Duration, bool) {
+	value, ok := d[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// parseCacheControl Cache-Control header'ını direktiflere ayırır
+func parseCacheControl(value string) cacheDirectives {
+	directives := make(cacheDirectives)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return directives
+}
+
+// parseVary Vary header değerlerini canonical header isimlerine çevirir
+func parseVary(values []string) []string {
+	var names []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// upstreamTTL upstream'in Cache-Control/Expires header'larından TTL hesaplar
+func upstreamTTL(directives cacheDirectives, header http.Header) time.Duration {
+	if directives.has("no-cache") {
+		return 0
+	}
+	if ttl, ok := directives.seconds("s-maxage"); ok {
+		return ttl
+	}
+	if ttl, ok := directives.seconds("max-age"); ok {
+		return ttl
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return time.Until(expires)
+	}
+	return 0
+}
+
+// etagMatches If-None-Match header'ının ETag ile eşleşip eşleşmediğini kontrol eder (weak karşılaştırma)
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
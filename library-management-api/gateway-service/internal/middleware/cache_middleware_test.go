@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"gateway-service/configs"
+	"gateway-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCacheMiddlewareSkipsIncompleteResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const body = `{"data":[]}`
+
+	tests := []struct {
+		name      string
+		upstream  gin.HandlerFunc
+		wantCache bool
+	}{
+		{
+			name: "tam yanıt",
+			upstream: func(c *gin.Context) {
+				c.Header("Content-Length", strconv.Itoa(len(body)))
+				c.String(http.StatusOK, body)
+			},
+			wantCache: true,
+		},
+		{
+			name: "content-length'siz yanıt",
+			upstream: func(c *gin.Context) {
+				c.String(http.StatusOK, body)
+			},
+			wantCache: true,
+		},
+		{
+			name: "eksik aktarılan body",
+			upstream: func(c *gin.Context) {
+				c.Header("Content-Length", strconv.Itoa(len(body)+10))
+				c.String(http.StatusOK, body)
+			},
+		},
+		{
+			name: "abort edilen istek",
+			upstream: func(c *gin.Context) {
+				c.String(http.StatusOK, body[:4])
+				c.Abort()
+			},
+		},
+		{
+			name: "hata eklenen istek",
+			upstream: func(c *gin.Context) {
+				c.String(http.StatusOK, body)
+				c.Error(errors.New("upstream body okunamadı"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := service.NewLRUResponseCache(1 << 20)
+			routes := staticRoutes{route: configs.RouteConfig{Cache: &configs.RouteCacheConfig{Enabled: true}}}
+			m := NewCacheMiddleware(cache, routes, configs.CacheConfig{MaxEntryBytes: 1 << 20})
+
+			r := gin.New()
+			r.GET("/api/books", m.Cache(), func(c *gin.Context) {
+				c.Header("Cache-Control", "max-age=60")
+				tt.upstream(c)
+			})
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/books", nil))
+
+			if got := cache.Stats().Entries; (got == 1) != tt.wantCache {
+				t.Errorf("cache'teki kayıt sayısı = %d, cache'lenmesi bekleniyor = %v", got, tt.wantCache)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// Desteklenen içerik kodlamaları; eşit tercih durumunda br seçilir
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// compressor yanıtı sıkıştıran ve havuza geri konabilen yazıcı
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// CompressionMiddleware Accept-Encoding ile br/gzip yanıt sıkıştırmasını ve
+// Content-Encoding ile gelen sıkıştırılmış istek body'lerinin açılmasını yönetir.
+// Cache middleware'inden sonra çalışır; sıkıştırılan yanıtlar Vary: Accept-Encoding sayesinde
+// cache'te kodlamaya göre ayrı varyantlar olarak saklanır.
+type CompressionMiddleware struct {
+	config       configs.CompressionConfig
+	contentTypes []string
+	pools        map[string]*sync.Pool
+}
+
+// NewCompressionMiddleware yeni compression middleware oluşturur
+func NewCompressionMiddleware(config configs.CompressionConfig) *CompressionMiddleware {
+	m := &CompressionMiddleware{config: config}
+	for _, contentType := range config.ContentTypes {
+		m.contentTypes = append(m.contentTypes, strings.ToLower(contentType))
+	}
+
+	// brotli seviyesi 0-11 aralığındadır; gzip seviyesi (1-9) orantılı olarak ölçeklenir
+	brotliLevel := (config.Level*brotli.BestCompression + 8) / 9
+	m.pools = map[string]*sync.Pool{
+		encodingGzip: {New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, config.Level)
+			return w
+		}},
+		encodingBrotli: {New: func() any {
+			return brotli.NewWriterLevel(io.Discard, brotliLevel)
+		}},
+	}
+	return m
+}
+
+// Compress istek body'sini açar ve yanıtı istemcinin kabul ettiği kodlamayla sıkıştırır
+func (m *CompressionMiddleware) Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.decodeRequest(c) {
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			middleware:     m,
+			encoding:       negotiateEncoding(c.Request.Header.Values("Accept-Encoding")),
+			head:           c.Request.Method == http.MethodHead,
+			status:         c.Writer.Status(),
+		}
+		c.Writer = writer
+		defer func() {
+			writer.finish()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// decodeRequest gzip/br ile sıkıştırılmış istek body'sini açılmış akışla değiştirir.
+// Desteklenmeyen kodlamalarda 415, bozuk gzip başlığında 400 döner.
+func (m *CompressionMiddleware) decodeRequest(c *gin.Context) bool {
+	encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return true
+	}
+
+	var decoded io.Reader
+	switch encoding {
+	case encodingGzip, "x-gzip":
+		reader, err := gzip.NewReader(c.Request.Body)
+		if err != nil {
+			requestid.Warnf(c.Request.Context(), "⚠️ Sıkıştırılmış istek body'si açılamadı: %v", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":       "INVALID_REQUEST_BODY",
+					"message":    "gzip istek body'si açılamadı",
+					"request_id": requestid.Get(c),
+				},
+			})
+			return false
+		}
+		decoded = reader
+	case encodingBrotli:
+		decoded = brotli.NewReader(c.Request.Body)
+	default:
+		// RFC 9110 15.5.16: desteklenen kodlamalar Accept-Encoding ile bildirilir
+		c.Header("Accept-Encoding", "gzip, br")
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": gin.H{
+				"code":       "UNSUPPORTED_CONTENT_ENCODING",
+				"message":    "Desteklenmeyen Content-Encoding: " + encoding,
+				"request_id": requestid.Get(c),
+			},
+		})
+		return false
+	}
+
+	body := &decodedBody{Reader: decoded, original: c.Request.Body}
+	c.Request.Body = http.MaxBytesReader(c.Writer, body, m.config.MaxRequestBytes)
+	c.Request.Header.Del("Content-Encoding")
+	c.Request.Header.Del("Content-Length")
+	c.Request.ContentLength = -1
+	return true
+}
+
+// decodedBody açılmış istek akışı; Close orijinal body'yi kapatır
+type decodedBody struct {
+	io.Reader
+	original io.ReadCloser
+}
+
+// Close orijinal istek body'sini kapatır
+func (b *decodedBody) Close() error {
+	return b.original.Close()
+}
+
+// compressible yanıt tipinin sıkıştırılacak tiplerden olup olmadığını kontrol eder
+func (m *CompressionMiddleware) compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range m.contentTypes {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateEncoding Accept-Encoding q-değerlerine göre br veya gzip seçer; uygun kodlama yoksa boş döner
+func negotiateEncoding(values []string) string {
+	weights := map[string]float64{}
+	wildcard := -1.0
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(item, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			q := 1.0
+			if key, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(key) == "q" {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					continue
+				}
+				q = parsed
+			}
+			switch name {
+			case "*":
+				wildcard = q
+			case "x-gzip":
+				weights[encodingGzip] = q
+			default:
+				weights[name] = q
+			}
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		q, ok := weights[encoding]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressWriter yanıtı eşik boyutuna kadar bekletir, ardından sıkıştırıp sıkıştırmayacağına karar verir.
+// Upstream'in zaten kodladığı (Content-Encoding), uygun tipte olmayan veya body taşımayan yanıtlar
+// olduğu gibi aktarılır.
+type compressWriter struct {
+	gin.ResponseWriter
+	middleware *CompressionMiddleware
+	encoding   string
+	head       bool
+
+	status    int
+	requested bool // handler header'ların yazılmasını istedi (WriteHeaderNow)
+	decided   bool
+	buffer    bytes.Buffer
+	size      int
+	writer    compressor
+}
+
+// WriteHeader karar verilene kadar durum kodunu saklar
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow karar verilene kadar header yazımını erteler
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.requested = true
+}
+
+// Status yanıt durum kodunu döner
+func (w *compressWriter) Status() int {
+	if w.decided {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+// Size handler'ın yazdığı (sıkıştırılmamış) body boyutunu döner
+func (w *compressWriter) Size() int {
+	if w.size == 0 && !w.Written() {
+		return -1
+	}
+	return w.size
+}
+
+// Written header'ların yazılıp yazılmadığını veya ertelendiğini döner
+func (w *compressWriter) Written() bool {
+	if w.decided {
+		return w.ResponseWriter.Written()
+	}
+	return w.requested || w.buffer.Len() > 0
+}
+
+// Write body'yi eşik aşılana kadar bekletir, karar verildikten sonra sıkıştırarak veya doğrudan yazar
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.size += len(data)
+	if !w.decided {
+		eligible := w.eligible()
+		if !eligible || w.encoding == "" {
+			w.decide(false, eligible)
+		} else if length, ok := w.contentLength(); ok {
+			w.decide(length >= w.middleware.config.MinBytes, true)
+		} else if w.buffer.Len()+len(data) >= w.middleware.config.MinBytes {
+			w.decide(true, true)
+		} else {
+			return w.buffer.Write(data)
+		}
+	}
+	if w.writer != nil {
+		return w.writer.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString string body'yi yazar
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush streaming yanıtlarda bekleyen veriyi gönderir; eşik beklenmeden sıkıştırma kararı verilir
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		eligible := w.eligible()
+		w.decide(eligible && w.encoding != "" && w.buffer.Len() > 0, eligible)
+	}
+	if w.writer != nil {
+		w.writer.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack bağlantıyı devralır (ör. WebSocket); sıkıştırma uygulanmaz
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !w.decided {
+		w.decided = true
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	return w.ResponseWriter.Hijack()
+}
+
+// eligible yanıtın sıkıştırmaya uygun olup olmadığını kontrol eder
+func (w *compressWriter) eligible() bool {
+	if w.head || w.status < http.StatusOK || w.status == http.StatusNoContent ||
+		w.status == http.StatusPartialContent || w.status == http.StatusNotModified {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	if strings.Contains(strings.ToLower(header.Get("Cache-Control")), "no-transform") {
+		return false
+	}
+	if strings.HasPrefix(header.Get("Content-Type"), "text/event-stream") {
+		// Her event'in sıkıştırıcıda beklemeden iletilmesi için SSE akışları sıkıştırılmaz
+		return false
+	}
+	return w.middleware.compressible(header.Get("Content-Type"))
+}
+
+// contentLength yanıtın bildirilen uzunluğunu döner
+func (w *compressWriter) contentLength() (int, bool) {
+	length, err := strconv.Atoi(w.Header().Get("Content-Length"))
+	return length, err == nil
+}
+
+// decide header'ları sıkıştırma kararına göre düzenler, durum kodunu yazar ve bekleyen body'yi gönderir.
+// vary true ise yanıt kodlamaya göre değişebildiğinden sıkıştırılmasa da Vary: Accept-Encoding eklenir.
+func (w *compressWriter) decide(compress, vary bool) {
+	w.decided = true
+	header := w.Header()
+	if vary {
+		addVary(header, "Accept-Encoding")
+	}
+	if compress {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// Sıkıştırılmış gösterim byte düzeyinde farklı olduğundan strong ETag weak'e çevrilir
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.writer = w.middleware.pools[w.encoding].Get().(compressor)
+		w.writer.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.requested {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	if w.buffer.Len() > 0 {
+		pending := w.buffer.Bytes()
+		if w.writer != nil {
+			w.writer.Write(pending)
+		} else {
+			w.ResponseWriter.Write(pending)
+		}
+		w.buffer.Reset()
+	}
+}
+
+// finish karar verilmediyse eşik altındaki yanıtı sıkıştırmadan gönderir ve sıkıştırıcıyı kapatıp havuza iade eder
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if !w.Written() {
+			// Body yazılmadı; durum kodu gin'in yazacağı şekilde aktarılır
+			w.ResponseWriter.WriteHeader(w.status)
+			return
+		}
+		w.decide(false, w.eligible())
+	}
+	if w.writer != nil {
+		w.writer.Close()
+		w.writer.Reset(io.Discard)
+		w.middleware.pools[w.encoding].Put(w.writer)
+		w.writer = nil
+	}
+}
+
+// addVary header Vary listesinde yoksa ekler
+func addVary(header http.Header, name string) {
+	for _, value := range parseVary(header.Values("Vary")) {
+		if value == "*" || value == http.CanonicalHeaderKey(name) {
+			return
+		}
+	}
+	header.Add("Vary", name)
+}
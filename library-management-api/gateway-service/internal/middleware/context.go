@@ -0,0 +1,22 @@
+package middleware
+
+// Gin context anahtarları
+const (
+	// ContextUserID doğrulanmış kullanıcı ID'si
+	ContextUserID = "user_id"
+	// ContextUsername doğrulanmış kullanıcı adı
+	ContextUsername = "username"
+	// ContextUserRoles doğrulanmış kullanıcı rolleri
+	ContextUserRoles = "user_roles"
+)
+
+// Upstream servislere gateway tarafından iletilen güvenilir kimlik header'ları
+const (
+	HeaderUserID    = "X-User-ID"
+	HeaderUsername  = "X-Username"
+	HeaderUserEmail = "X-User-Email"
+	HeaderUserRoles = "X-User-Roles"
+)
+
+// IdentityHeaders istemciden gelirse silinen, sadece gateway'in set ettiği header'lar
+var IdentityHeaders = []string{HeaderUserID, HeaderUsername, HeaderUserEmail, HeaderUserRoles}
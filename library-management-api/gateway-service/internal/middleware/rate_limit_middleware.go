@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware route gruplarına göre token bucket rate limit uygular
+type RateLimitMiddleware struct {
+	store        service.RateLimitStore
+	routeService service.RouteService
+	config       configs.RateLimitStoreConfig
+}
+
+// NewRateLimitMiddleware yeni rate limit middleware oluşturur
+func NewRateLimitMiddleware(store service.RateLimitStore, routeService service.RouteService, config configs.RateLimitStoreConfig) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		store:        store,
+		routeService: routeService,
+		config:       config,
+	}
+}
+
+// Limit isteğin route'una ait rate limit grubunu uygular; limit aşılırsa 429 döner
+func (m *RateLimitMiddleware) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := m.routeService.Match(c.Request.URL.Path)
+		if !ok || route.RateLimitPolicy() == nil {
+			c.Next()
+			return
+		}
+
+		if !m.take(c, route.RateLimitPolicy(), route.Prefix) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// LimitGroup route tablosu dışındaki endpoint'lere (composite, GraphQL) route dosyasındaki rate limit grubunu uygular.
+// Grup route dosyasında yoksa route'lardaki "default" davranışıyla aynı şekilde limit uygulanmaz.
+func (m *RateLimitMiddleware) LimitGroup(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, ok := m.routeService.RateLimit(group)
+		if !ok {
+			c.Next()
+			return
+		}
+		if !m.take(c, policy, c.FullPath()) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// take istek için bucket'tan token alır ve RateLimit-* header'larını yazar; limit aşıldıysa 429 yanıtı yazıp false döner.
+// routeKey "route" anahtarlı gruplarda tüm istemcilerin paylaştığı bucket'ı belirler.
+func (m *RateLimitMiddleware) take(c *gin.Context, policy *configs.RateLimitConfig, routeKey string) bool {
+	key := policy.Group + ":" + policy.Key + ":" + m.clientKey(c, routeKey, policy)
+	result, err := m.store.Take(c.Request.Context(), key, policy.RequestsPerSecond, policy.Burst)
+	if err != nil {
+		// Store'a ulaşılamadığında limit uygulanamaz; fail-open'da istek geçer, fail-closed'da 503 döner
+		if m.config.FailOpen {
+			requestid.Warnf(c.Request.Context(), "⚠️ [%s] Rate limit store hatası, istek limitsiz geçiyor: %v", policy.Group, err)
+			return true
+		}
+		requestid.Errorf(c.Request.Context(), "❌ [%s] Rate limit store hatası, istek reddedildi: %v", policy.Group, err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":       "RATE_LIMIT_UNAVAILABLE",
+				"message":    "İstek limiti şu an doğrulanamıyor, lütfen daha sonra tekrar deneyin",
+				"request_id": requestid.Get(c),
+				"group":      policy.Group,
+			},
+		})
+		return false
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":       "RATE_LIMIT_EXCEEDED",
+				"message":    "İstek limiti aşıldı, lütfen daha sonra tekrar deneyin",
+				"request_id": requestid.Get(c),
+				"group":      policy.Group,
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// clientKey rate limit anahtarının değerini belirler.
+// Kullanıcı anahtarı doğrulanmış kimlikten okunur; kimlik yoksa IP'ye düşülür.
+func (m *RateLimitMiddleware) clientKey(c *gin.Context, routeKey string, policy *configs.RateLimitConfig) string {
+	switch policy.Key {
+	case configs.RateLimitKeyRoute:
+		return routeKey
+	case configs.RateLimitKeyUser:
+		if userID := c.GetString(ContextUserID); userID != "" {
+			return "user:" + userID
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds süreyi yukarı yuvarlanmış saniyeye çevirir
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// AuditEntry yönetim API'si üzerinden yapılan tek bir değişikliğin kaydı
+type AuditEntry struct {
+	Time      time.Time   `json:"time"`
+	RequestID string      `json:"request_id,omitempty"`
+	Actor     string      `json:"actor"`
+	Action    string      `json:"action"`
+	Target    string      `json:"target"`
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+}
+
+// AuditLog yönetim değişikliklerini kaydeden interface
+type AuditLog interface {
+	Record(entry AuditEntry)
+	Entries() []AuditEntry
+}
+
+// MemoryAuditLog son değişiklikleri bellekte tutan ve her kaydı log'a yazan AuditLog implementasyonu
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+	next    int
+	full    bool
+}
+
+// NewMemoryAuditLog en fazla capacity kayıt tutan audit log oluşturur
+func NewMemoryAuditLog(capacity int) AuditLog {
+	return &MemoryAuditLog{
+		entries: make([]AuditEntry, capacity),
+	}
+}
+
+// Record değişikliği kaydeder; audit satırları log seviyesinden bağımsız olarak her zaman yazılır
+func (l *MemoryAuditLog) Record(entry AuditEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	log.Printf("📝 [audit] %s %s: %v -> %v (actor=%s, request_id=%s)",
+		entry.Action, entry.Target, entry.Before, entry.After, entry.Actor, entry.RequestID)
+
+	if len(l.entries) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Entries kayıtları en yeniden en eskiye doğru döner
+func (l *MemoryAuditLog) Entries() []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.entries)
+	}
+	result := make([]AuditEntry, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return result
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+)
+
+// Circuit breaker durumları
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// CallOutcome upstream çağrısının circuit breaker'a bildirilen sonucu
+type CallOutcome int
+
+const (
+	// OutcomeSuccess upstream başarılı yanıt verdi
+	OutcomeSuccess CallOutcome = iota
+	// OutcomeFailure bağlantı hatası, zaman aşımı veya 5xx yanıt
+	OutcomeFailure
+	// OutcomeIgnored upstream'den bağımsız sonuç (ör. istemci iptali), sayılmaz
+	OutcomeIgnored
+)
+
+// ErrCircuitOpen circuit açıkken gelen istekler için döner
+var ErrCircuitOpen = errors.New("circuit açık, upstream geçici olarak devre dışı")
+
+// CircuitStatus circuit breaker'ın anlık durumu
+type CircuitStatus struct {
+	State      string    `json:"state"`
+	Requests   int       `json:"requests"`
+	Failures   int       `json:"failures"`
+	LastChange time.Time `json:"last_change"`
+	RetryAfter string    `json:"retry_after,omitempty"`
+}
+
+// CircuitBreaker tek upstream için closed/open/half-open circuit breaker
+type CircuitBreaker struct {
+	mu     sync.Mutex
+	name   string
+	config configs.CircuitBreakerConfig
+
+	state       string
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	lastChange  time.Time
+
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+}
+
+// CircuitBreakerRegistry upstream bazlı circuit breaker'ları yönetir
+type CircuitBreakerRegistry interface {
+	Allow(upstream string) (func(CallOutcome), error)
+	RetryAfter(upstream string) time.Duration
+	Status() map[string]CircuitStatus
+}
+
+// CircuitBreakerRegistryImpl CircuitBreakerRegistry implementasyonu
+type CircuitBreakerRegistryImpl struct {
+	mu       sync.Mutex
+	config   configs.CircuitBreakerConfig
+	breakers map[string]*CircuitBreaker
+}
+
+// NewCircuitBreakerRegistry yeni circuit breaker registry oluşturur
+func NewCircuitBreakerRegistry(config configs.CircuitBreakerConfig) CircuitBreakerRegistry {
+	return &CircuitBreakerRegistryImpl{
+		config:   config,
+		breakers: make(map[string]*CircuitBreaker),
+	}
+}
+
+// Allow upstream'e istek gönderilip gönderilemeyeceğini kontrol eder.
+// İzin verilirse dönen fonksiyon çağrı bitince sonuçla birlikte çağrılmalıdır.
+func (r *CircuitBreakerRegistryImpl) Allow(upstream string) (func(CallOutcome), error) {
+	if !r.config.Enabled {
+		return func(CallOutcome) {}, nil
+	}
+	return r.breaker(upstream).allow()
+}
+
+// RetryAfter açık circuit'in tekrar deneneceği zamana kalan süreyi döner
+func (r *CircuitBreakerRegistryImpl) RetryAfter(upstream string) time.Duration {
+	return r.breaker(upstream).retryAfter()
+}
+
+// Status tüm circuit breaker'ların durumunu döner
+func (r *CircuitBreakerRegistryImpl) Status() map[string]CircuitStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make(map[string]CircuitStatus, len(r.breakers))
+	for name, breaker := range r.breakers {
+		result[name] = breaker.status()
+	}
+	return result
+}
+
+// breaker upstream için circuit breaker'ı döner, yoksa oluşturur
+func (r *CircuitBreakerRegistryImpl) breaker(upstream string) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breaker, ok := r.breakers[upstream]
+	if !ok {
+		now := time.Now()
+		breaker = &CircuitBreaker{
+			name:        upstream,
+			config:      r.config,
+			state:       CircuitClosed,
+			windowStart: now,
+			lastChange:  now,
+		}
+		r.breakers[upstream] = breaker
+	}
+	return breaker
+}
+
+// allow circuit durumuna göre isteğe izin verir
+func (b *CircuitBreaker) allow() (func(CallOutcome), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.state == CircuitOpen {
+		if now.Sub(b.openedAt) < b.config.CoolDown {
+			return nil, ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen, now)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.halfOpenInFlight+b.halfOpenSuccesses >= b.config.HalfOpenRequests {
+			return nil, ErrCircuitOpen
+		}
+		b.halfOpenInFlight++
+	}
+
+	generation := b.generation
+	var once sync.Once
+	return func(outcome CallOutcome) {
+		once.Do(func() { b.record(generation, outcome) })
+	}, nil
+}
+
+// record çağrı sonucunu circuit'e işler; durum değiştiyse eski nesilden gelen sonuçlar yok sayılır
+func (b *CircuitBreaker) record(generation uint64, outcome CallOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	now := time.Now()
+	switch b.state {
+	case CircuitHalfOpen:
+		b.halfOpenInFlight--
+		switch outcome {
+		case OutcomeFailure:
+			b.setState(CircuitOpen, now)
+		case OutcomeSuccess:
+			b.halfOpenSuccesses++
+			if b.halfOpenSuccesses >= b.config.HalfOpenRequests {
+				b.setState(CircuitClosed, now)
+			}
+		}
+
+	case CircuitClosed:
+		if outcome == OutcomeIgnored {
+			return
+		}
+		if now.Sub(b.windowStart) > b.config.Window {
+			b.windowStart = now
+			b.requests = 0
+			b.failures = 0
+		}
+
+		b.requests++
+		if outcome == OutcomeFailure {
+			b.failures++
+		}
+
+		if b.requests >= b.config.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.config.FailureRatio {
+			b.setState(CircuitOpen, now)
+		}
+	}
+}
+
+// setState durum geçişini yapar ve sayaçları sıfırlar
+func (b *CircuitBreaker) setState(state string, now time.Time) {
+	b.state = state
+	b.generation++
+	b.lastChange = now
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+	b.halfOpenInFlight = 0
+	b.halfOpenSuccesses = 0
+
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+
+	logCircuitTransition(b.name, state)
+}
+
+// retryAfter açık circuit için cool-down'ın bitmesine kalan süreyi döner
+func (b *CircuitBreaker) retryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitOpen {
+		return 0
+	}
+	remaining := b.config.CoolDown - time.Since(b.openedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// status circuit'in anlık durumunu döner
+func (b *CircuitBreaker) status() CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitStatus{
+		State:      b.state,
+		Requests:   b.requests,
+		Failures:   b.failures,
+		LastChange: b.lastChange,
+	}
+	if b.state == CircuitOpen {
+		if remaining := b.config.CoolDown - time.Since(b.openedAt); remaining > 0 {
+			status.RetryAfter = remaining.Round(time.Second).String()
+		}
+	}
+	return status
+}
+
+// logCircuitTransition circuit durum değişikliklerini loglar
+func logCircuitTransition(upstream, state string) {
+	switch state {
+	case CircuitOpen:
+		logging.Warnf("🔴 [%s] Circuit açıldı, istekler hızlıca reddedilecek", upstream)
+	case CircuitHalfOpen:
+		logging.Infof("🟡 [%s] Circuit yarı açık, deneme istekleri gönderiliyor", upstream)
+	case CircuitClosed:
+		logging.Infof("🟢 [%s] Circuit kapandı, upstream tekrar kullanılabilir", upstream)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gateway-service/configs"
+)
+
+const testCoolDown = 20 * time.Millisecond
+
+func newTestCircuitBreakers() CircuitBreakerRegistry {
+	return NewCircuitBreakerRegistry(configs.CircuitBreakerConfig{
+		Enabled:          true,
+		FailureRatio:     0.5,
+		MinRequests:      4,
+		Window:           time.Minute,
+		CoolDown:         testCoolDown,
+		HalfOpenRequests: 2,
+	})
+}
+
+// call upstream'e izin alıp sonucu hemen bildirir; circuit açıksa hata döner
+func call(registry CircuitBreakerRegistry, outcome CallOutcome) error {
+	done, err := registry.Allow(testUpstream)
+	if err != nil {
+		return err
+	}
+	done(outcome)
+	return nil
+}
+
+func TestCircuitBreakerClosedState(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcomes []CallOutcome
+		want     string
+	}{
+		{
+			name:     "minimum istek sayısına ulaşılmadan açılmaz",
+			outcomes: []CallOutcome{OutcomeFailure, OutcomeFailure, OutcomeFailure},
+			want:     CircuitClosed,
+		},
+		{
+			name:     "hata oranı eşiğin altında",
+			outcomes: []CallOutcome{OutcomeFailure, OutcomeSuccess, OutcomeSuccess, OutcomeSuccess},
+			want:     CircuitClosed,
+		},
+		{
+			name:     "hata oranı eşiğe ulaşınca açılır",
+			outcomes: []CallOutcome{OutcomeSuccess, OutcomeFailure, OutcomeSuccess, OutcomeFailure},
+			want:     CircuitOpen,
+		},
+		{
+			name:     "yok sayılan sonuçlar sayılmaz",
+			outcomes: []CallOutcome{OutcomeFailure, OutcomeIgnored, OutcomeIgnored, OutcomeFailure, OutcomeIgnored},
+			want:     CircuitClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestCircuitBreakers()
+			for _, outcome := range tt.outcomes {
+				if err := call(registry, outcome); err != nil {
+					t.Fatalf("Allow: %v", err)
+				}
+			}
+			if got := registry.Status()[testUpstream].State; got != tt.want {
+				t.Errorf("durum = %s, beklenen %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// openCircuit circuit'i art arda hatalarla açar
+func openCircuit(t *testing.T, registry CircuitBreakerRegistry) {
+	t.Helper()
+	for i := 0; i < 4; i++ {
+		if err := call(registry, OutcomeFailure); err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+	}
+	if err := call(registry, OutcomeSuccess); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("açık circuit isteğe izin verdi: %v", err)
+	}
+	if retryAfter := registry.RetryAfter(testUpstream); retryAfter <= 0 || retryAfter > testCoolDown {
+		t.Fatalf("RetryAfter = %v", retryAfter)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcomes []CallOutcome
+		want     string
+	}{
+		{name: "yeterli başarı circuit'i kapatır", outcomes: []CallOutcome{OutcomeSuccess, OutcomeSuccess}, want: CircuitClosed},
+		{name: "tek hata circuit'i tekrar açar", outcomes: []CallOutcome{OutcomeSuccess, OutcomeFailure}, want: CircuitOpen},
+		{name: "eksik başarıda yarı açık kalır", outcomes: []CallOutcome{OutcomeSuccess}, want: CircuitHalfOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestCircuitBreakers()
+			openCircuit(t, registry)
+			time.Sleep(testCoolDown)
+
+			for _, outcome := range tt.outcomes {
+				if err := call(registry, outcome); err != nil {
+					t.Fatalf("Allow: %v", err)
+				}
+			}
+			if got := registry.Status()[testUpstream].State; got != tt.want {
+				t.Errorf("durum = %s, beklenen %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenLimitsProbes(t *testing.T) {
+	registry := newTestCircuitBreakers()
+	openCircuit(t, registry)
+	time.Sleep(testCoolDown)
+
+	first, err := registry.Allow(testUpstream)
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	second, err := registry.Allow(testUpstream)
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	if _, err := registry.Allow(testUpstream); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("yarı açık circuit deneme limitini aştı: %v", err)
+	}
+
+	// Sonucu iki kez bildirmek sayaçları bozmaz
+	first(OutcomeSuccess)
+	first(OutcomeSuccess)
+	if got := registry.Status()[testUpstream].State; got != CircuitHalfOpen {
+		t.Fatalf("durum = %s, beklenen %s", got, CircuitHalfOpen)
+	}
+	second(OutcomeSuccess)
+	if got := registry.Status()[testUpstream].State; got != CircuitClosed {
+		t.Errorf("durum = %s, beklenen %s", got, CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	registry := newTestCircuitBreakers()
+
+	// Circuit açılmadan önce başlayan istek, açıldıktan sonra sonuçlanır
+	stale, err := registry.Allow(testUpstream)
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	openCircuit(t, registry)
+	time.Sleep(testCoolDown)
+	if err := call(registry, OutcomeSuccess); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+
+	stale(OutcomeFailure)
+	if got := registry.Status()[testUpstream].State; got != CircuitHalfOpen {
+		t.Errorf("eski nesilden gelen hata durumu değiştirdi: %s", got)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	registry := NewCircuitBreakerRegistry(configs.CircuitBreakerConfig{Enabled: false})
+	for i := 0; i < 10; i++ {
+		if err := call(registry, OutcomeFailure); err != nil {
+			t.Fatalf("kapalı circuit breaker isteği reddetti: %v", err)
+		}
+	}
+	if len(registry.Status()) != 0 {
+		t.Errorf("kapalı circuit breaker durum tuttu: %v", registry.Status())
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/metrics"
+	"gateway-service/internal/requestid"
+)
+
+// Composite bölüm durumları
+const (
+	SectionOK          = "ok"
+	SectionNotFound    = "not_found"
+	SectionUnavailable = "unavailable"
+	SectionTimeout     = "timeout"
+	SectionSkipped     = "skipped"
+)
+
+// ErrCompositeNotFound birleşik yanıtın ana kaynağı (ör. kitap) bulunamadığında döner
+var ErrCompositeNotFound = errors.New("kaynak bulunamadı")
+
+// ErrCompositeUnavailable birleşik yanıtın ana kaynağı alınamadığında döner
+var ErrCompositeUnavailable = errors.New("ana kaynak alınamadı")
+
+// CompositeSection birleşik yanıttaki tek bir bölümün sonucu
+type CompositeSection struct {
+	Status    string  `json:"status"`
+	Service   string  `json:"service"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// BookDetail kitap, yazar, tür ve öneri bilgilerinin tek payload'da birleşimi.
+// Alınamayan bölümler null döner, nedeni Sections altında belirtilir.
+type BookDetail struct {
+	Book            json.RawMessage              `json:"book"`
+	Author          json.RawMessage              `json:"author"`
+	Genre           json.RawMessage              `json:"genre"`
+	Recommendations json.RawMessage              `json:"recommendations"`
+	Sections        map[string]*CompositeSection `json:"sections"`
+	Partial         bool                         `json:"partial"`
+}
+
+// CompositeService birden fazla servisin yanıtlarını gateway'de birleştiren interface
+type CompositeService interface {
+	BookDetail(ctx context.Context, bookID int, header http.Header) (*BookDetail, error)
+}
+
+// CompositeServiceImpl CompositeService implementasyonu
+type CompositeServiceImpl struct {
+	proxyService ProxyService
+	config       configs.CompositeConfig
+}
+
+// NewCompositeService yeni composite service oluşturur
+func NewCompositeService(proxyService ProxyService, config configs.CompositeConfig) CompositeService {
+	return &CompositeServiceImpl{
+		proxyService: proxyService,
+		config:       config,
+	}
+}
+
+// BookDetail önce kitabı getirir, ardından yazar, tür ve önerileri eşzamanlı olarak toplar.
+// Kitap alınamazsa hata döner; diğer bölümlerdeki hatalar yanıtı bozmaz, bölüm eksik olarak işaretlenir.
+func (s *CompositeServiceImpl) BookDetail(ctx context.Context, bookID int, header http.Header) (*BookDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	detail := &BookDetail{Sections: make(map[string]*CompositeSection, 4)}
+
+	book, section := s.fetchSection(ctx, "book-service", fmt.Sprintf("/api/books/simple/%d", bookID), header)
+	detail.Sections["book"] = section
+	switch section.Status {
+	case SectionOK:
+		detail.Book = book
+	case SectionNotFound:
+		return detail, ErrCompositeNotFound
+	default:
+		return detail, fmt.Errorf("%w: %s", ErrCompositeUnavailable, section.Error)
+	}
+
+	var ref struct {
+		Author       string `json:"author"`
+		CategoryName string `json:"category_name"`
+	}
+	if err := json.Unmarshal(book, &ref); err != nil {
+		return detail, fmt.Errorf("%w: kitap yanıtı parse edilemedi: %v", ErrCompositeUnavailable, err)
+	}
+
+	var wg sync.WaitGroup
+	var authorSection, genreSection, recommendationSection *CompositeSection
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		if ref.Author == "" {
+			authorSection = skippedSection("author-service", "kitabın yazar bilgisi yok")
+			return
+		}
+		detail.Author, authorSection = s.fetchSection(ctx, "author-service", "/api/authors/detail/"+url.PathEscape(ref.Author), header)
+	}()
+	go func() {
+		defer wg.Done()
+		if ref.CategoryName == "" {
+			genreSection = skippedSection("genre-service", "kitabın kategori bilgisi yok")
+			return
+		}
+		detail.Genre, genreSection = s.fetchSection(ctx, "genre-service", "/api/genres/detail/"+url.PathEscape(ref.CategoryName), header)
+	}()
+	go func() {
+		defer wg.Done()
+		query := url.Values{}
+		query.Set("limit", fmt.Sprint(s.config.RecommendationLimit))
+		if ref.CategoryName != "" {
+			query.Set("category", ref.CategoryName)
+		}
+		detail.Recommendations, recommendationSection = s.fetchSection(ctx, "recommendation-service", "/api/recommendations/by-category?"+query.Encode(), header)
+	}()
+	wg.Wait()
+
+	detail.Sections["author"] = authorSection
+	detail.Sections["genre"] = genreSection
+	detail.Sections["recommendations"] = recommendationSection
+	for name, section := range detail.Sections {
+		if section.Status != SectionOK {
+			detail.Partial = true
+			requestid.Warnf(ctx, "⚠️ [composite] Kitap %d için %s bölümü eksik: %s (%s)", bookID, name, section.Status, section.Error)
+		}
+	}
+
+	return detail, nil
+}
+
+// fetchSection upstream'den tek bir bölümü getirir ve yanıt envelope'undaki data alanını döner
+func (s *CompositeServiceImpl) fetchSection(ctx context.Context, upstream, path string, header http.Header) (json.RawMessage, *CompositeSection) {
+	section := &CompositeSection{Service: upstream}
+	start := time.Now()
+
+	resp, err := s.proxyService.Fetch(ctx, upstream, path, header)
+	section.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		section.Status = SectionUnavailable
+		if UpstreamErrorReason(ctx, err) == metrics.ReasonTimeout {
+			section.Status = SectionTimeout
+		}
+		section.Error = err.Error()
+		return nil, section
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		section.Status = SectionNotFound
+		section.Error = fmt.Sprintf("%s kaydı bulunamadı", upstream)
+		return nil, section
+	}
+	if resp.StatusCode != http.StatusOK {
+		section.Status = SectionUnavailable
+		section.Error = fmt.Sprintf("beklenmeyen durum kodu: %d", resp.StatusCode)
+		return nil, section
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body, &envelope); err != nil || len(envelope.Data) == 0 {
+		section.Status = SectionUnavailable
+		section.Error = "yanıtta data alanı yok"
+		return nil, section
+	}
+
+	section.Status = SectionOK
+	return envelope.Data, section
+}
+
+// skippedSection bağımlı olduğu bilgi olmadığı için çağrılmayan bölüm
+func skippedSection(upstream, reason string) *CompositeSection {
+	return &CompositeSection{Status: SectionSkipped, Service: upstream, Error: reason}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hopByHopHeaders sadece tek bağlantı için anlamlı olan ve proxy'de iletilmeyen header'lar (RFC 9110 7.6.1)
+var hopByHopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// forwardingHeaders istemcinin ve önceki proxy'lerin adresini taşıyan, gateway'in yeniden oluşturduğu header'lar
+var forwardingHeaders = []string{
+	"X-Forwarded-For",
+	"X-Forwarded-Proto",
+	"X-Forwarded-Host",
+	"Forwarded",
+	"X-Real-IP",
+}
+
+// gatewayRequestHeaders gateway'in set ettiği, route header politikasından etkilenmeyen istek header'ları
+var gatewayRequestHeaders = headerSet(
+	requestid.Header,
+	configs.HeaderAPIVersion,
+	"X-User-ID",
+	"X-Username",
+	"X-User-Email",
+	"X-User-Roles",
+)
+
+// gatewayResponseHeaders gateway'in kendisinin yönettiği, upstream yanıtından alınmayan header'lar
+var gatewayResponseHeaders = headerSet(
+	requestid.Header,
+	"X-Cache",
+	"X-Retry-Count",
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+)
+
+// fetchHeaders gateway'in yanıtı kendisi okuduğu çağrılarda (Fetch) aktarılan istemci header'ları.
+// Accept-Encoding, If-None-Match gibi yanıtın şeklini değiştiren header'lar bilinçli olarak aktarılmaz.
+var fetchHeaders = []string{
+	"Authorization",
+	"Accept-Language",
+	"User-Agent",
+	requestid.Header,
+	"X-User-ID",
+	"X-Username",
+	"X-User-Email",
+	"X-User-Roles",
+}
+
+// headerSet header adlarını kanonik halleriyle arama tablosuna çevirir
+func headerSet(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[http.CanonicalHeaderKey(name)] = true
+	}
+	return set
+}
+
+// removeHopByHop hop-by-hop header'larını ve Connection header'ında listelenenleri siler
+func removeHopByHop(header http.Header) {
+	for _, value := range header.Values("Connection") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				header.Del(name)
+			}
+		}
+	}
+	for _, name := range hopByHopHeaders {
+		header.Del(name)
+	}
+}
+
+// forwarder upstream'e giden isteklerin X-Forwarded-* ve Forwarded (RFC 7239) header'larını oluşturur.
+// Doğrudan bağlanan istemci güvenilir bir proxy ise gelen zincir korunup sonuna eklenir; değilse
+// istemcinin gönderdiği forwarding header'ları yok sayılır ve zincir yeniden başlatılır.
+type forwarder struct {
+	trusted []*net.IPNet
+}
+
+// newForwarder güvenilir proxy IP/CIDR listesiyle yeni forwarder oluşturur; geçersiz girişler yok sayılır
+func newForwarder(trustedProxies []string) *forwarder {
+	f := &forwarder{}
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			f.trusted = append(f.trusted, network)
+		}
+	}
+	return f
+}
+
+// trustedPeer doğrudan bağlanan istemcinin güvenilir proxy olup olmadığını kontrol eder
+func (f *forwarder) trustedPeer(ip net.IP) bool {
+	for _, network := range f.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply istemci isteğine göre upstream isteğinin forwarding header'larını set eder
+func (f *forwarder) apply(c *gin.Context, dst http.Header) {
+	src := c.Request.Header
+	peer := c.RemoteIP()
+	trusted := f.trustedPeer(net.ParseIP(peer))
+
+	proto := "http"
+	if c.Request.TLS != nil {
+		proto = "https"
+	}
+	host := c.Request.Host
+
+	if trusted {
+		if value := src.Get("X-Forwarded-Proto"); value != "" {
+			proto = value
+		}
+		if value := src.Get("X-Forwarded-Host"); value != "" {
+			host = value
+		}
+	}
+
+	forwardedFor := peer
+	forwarded := forwardedElement(peer, host, proto)
+	if trusted {
+		if prior := strings.Join(src.Values("X-Forwarded-For"), ", "); prior != "" {
+			forwardedFor = prior + ", " + peer
+		}
+		if prior := strings.Join(src.Values("Forwarded"), ", "); prior != "" {
+			forwarded = prior + ", " + forwarded
+		}
+	}
+
+	for _, name := range forwardingHeaders {
+		dst.Del(name)
+	}
+	dst.Set("X-Forwarded-For", forwardedFor)
+	dst.Set("X-Forwarded-Proto", proto)
+	dst.Set("X-Forwarded-Host", host)
+	dst.Set("Forwarded", forwarded)
+	dst.Set("X-Real-IP", c.ClientIP())
+}
+
+// forwardedElement RFC 7239 Forwarded header'ının tek bir proxy adımını oluşturur
+func forwardedElement(peer, host, proto string) string {
+	node := peer
+	if strings.Contains(peer, ":") {
+		// IPv6 adresleri köşeli parantez içinde ve tırnaklı yazılır
+		node = `"[` + peer + `]"`
+	}
+	return "for=" + node + `;host="` + host + `";proto=` + proto
+}
+
+// copyRequestHeaders istemci header'larını upstream isteğine kopyalar: hop-by-hop ve forwarding header'ları
+// çıkarılır, route'un request header kuralları gateway'in kendi set ettiği header'lar dışındakilere uygulanır
+func copyRequestHeaders(src, dst http.Header, rules configs.HeaderRules) {
+	for name, values := range src {
+		if !gatewayRequestHeaders[name] && !rules.Permits(name) {
+			continue
+		}
+		dst[name] = append([]string(nil), values...)
+	}
+	removeHopByHop(dst)
+	for _, name := range forwardingHeaders {
+		dst.Del(name)
+	}
+}
+
+// copyResponseHeaders upstream yanıt header'larını istemci yanıtına kopyalar: hop-by-hop header'lar,
+// gateway'in yönettiği header'lar (CORS, rate limit, cache durumu) ve route'un reddettiği header'lar aktarılmaz
+func copyResponseHeaders(src, dst http.Header, rules configs.HeaderRules) {
+	connection := src.Values("Connection")
+	for name, values := range src {
+		if gatewayResponseHeaders[name] || strings.HasPrefix(name, "Access-Control-") || !rules.Permits(name) {
+			continue
+		}
+		if isConnectionHeader(name, connection) {
+			continue
+		}
+		dst[name] = append([]string(nil), values...)
+	}
+}
+
+// isConnectionHeader header'ın hop-by-hop olup olmadığını (sabit liste veya Connection token'ı) kontrol eder
+func isConnectionHeader(name string, connection []string) bool {
+	for _, hop := range hopByHopHeaders {
+		if name == hop {
+			return true
+		}
+	}
+	for _, value := range connection {
+		for _, token := range strings.Split(value, ",") {
+			if http.CanonicalHeaderKey(strings.TrimSpace(token)) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requestHeaderBytes istek satırı ve header'ların boyutunu http.Server.MaxHeaderBytes ile aynı şekilde hesaplar
+func requestHeaderBytes(r *http.Request) int {
+	size := len(r.Method) + len(r.RequestURI) + len(r.Proto) + 4
+	for name, values := range r.Header {
+		for _, value := range values {
+			size += len(name) + len(value) + 4
+		}
+	}
+	return size
+}
+
+// copyFetchHeaders gateway'in kendi adına yaptığı çağrılara sadece kimlik, dil ve istek ID'si header'larını kopyalar
+func copyFetchHeaders(src, dst http.Header) {
+	for _, name := range fetchHeaders {
+		for _, value := range src.Values(name) {
+			dst.Add(name, value)
+		}
+	}
+}
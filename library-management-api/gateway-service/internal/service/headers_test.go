@@ -0,0 +1,164 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gateway-service/configs"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRemoveHopByHop(t *testing.T) {
+	header := http.Header{
+		"Connection":        {"keep-alive, X-Debug-Token"},
+		"Keep-Alive":        {"timeout=5"},
+		"Te":                {"trailers"},
+		"Transfer-Encoding": {"chunked"},
+		"Upgrade":           {"h2c"},
+		"X-Debug-Token":     {"abc"},
+		"Accept":            {"application/json"},
+	}
+	removeHopByHop(header)
+
+	if len(header) != 1 || header.Get("Accept") != "application/json" {
+		t.Errorf("kalan header'lar = %v, sadece Accept kalmalı", header)
+	}
+}
+
+func TestForwarderApply(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	forwarder := newForwarder([]string{"10.0.0.0/8", "192.168.1.5", "not-an-ip"})
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		headers       map[string]string
+		wantFor       string
+		wantProto     string
+		wantHost      string
+		wantForwarded string
+	}{
+		{
+			name:          "doğrudan istemci",
+			remoteAddr:    "203.0.113.7:5000",
+			wantFor:       "203.0.113.7",
+			wantProto:     "http",
+			wantHost:      "api.example.com",
+			wantForwarded: `for=203.0.113.7;host="api.example.com";proto=http`,
+		},
+		{
+			name:       "güvenilmeyen istemcinin forwarding header'ları yok sayılır",
+			remoteAddr: "203.0.113.7:5000",
+			headers: map[string]string{
+				"X-Forwarded-For": "1.2.3.4", "X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example.com", "Forwarded": "for=1.2.3.4",
+			},
+			wantFor:       "203.0.113.7",
+			wantProto:     "http",
+			wantHost:      "api.example.com",
+			wantForwarded: `for=203.0.113.7;host="api.example.com";proto=http`,
+		},
+		{
+			name:       "güvenilir proxy zinciri korunur ve sonuna eklenir",
+			remoteAddr: "10.1.2.3:5000",
+			headers: map[string]string{
+				"X-Forwarded-For": "198.51.100.1, 172.16.0.1", "X-Forwarded-Proto": "https", "X-Forwarded-Host": "library.example.com", "Forwarded": "for=198.51.100.1",
+			},
+			wantFor:       "198.51.100.1, 172.16.0.1, 10.1.2.3",
+			wantProto:     "https",
+			wantHost:      "library.example.com",
+			wantForwarded: `for=198.51.100.1, for=10.1.2.3;host="library.example.com";proto=https`,
+		},
+		{
+			name:          "tek IP olarak tanımlı güvenilir proxy",
+			remoteAddr:    "192.168.1.5:5000",
+			headers:       map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			wantFor:       "198.51.100.1, 192.168.1.5",
+			wantProto:     "http",
+			wantHost:      "api.example.com",
+			wantForwarded: `for=192.168.1.5;host="api.example.com";proto=http`,
+		},
+		{
+			name:          "IPv6 istemci",
+			remoteAddr:    "[2001:db8::1]:5000",
+			wantFor:       "2001:db8::1",
+			wantProto:     "http",
+			wantHost:      "api.example.com",
+			wantForwarded: `for="[2001:db8::1]";host="api.example.com";proto=http`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "http://api.example.com/api/books", nil)
+			c.Request.RemoteAddr = tt.remoteAddr
+			for name, value := range tt.headers {
+				c.Request.Header.Set(name, value)
+			}
+
+			dst := http.Header{}
+			copyRequestHeaders(c.Request.Header, dst, configs.HeaderRules{})
+			forwarder.apply(c, dst)
+
+			if got := dst.Get("X-Forwarded-For"); got != tt.wantFor {
+				t.Errorf("X-Forwarded-For = %q, beklenen %q", got, tt.wantFor)
+			}
+			if got := dst.Get("X-Forwarded-Proto"); got != tt.wantProto {
+				t.Errorf("X-Forwarded-Proto = %q, beklenen %q", got, tt.wantProto)
+			}
+			if got := dst.Get("X-Forwarded-Host"); got != tt.wantHost {
+				t.Errorf("X-Forwarded-Host = %q, beklenen %q", got, tt.wantHost)
+			}
+			if got := dst.Get("Forwarded"); got != tt.wantForwarded {
+				t.Errorf("Forwarded = %q, beklenen %q", got, tt.wantForwarded)
+			}
+		})
+	}
+}
+
+func TestCopyHeadersWithRouteRules(t *testing.T) {
+	routes := []configs.RouteConfig{{
+		Prefix:   "/api/books",
+		Upstream: "book-service",
+		Headers: &configs.HeaderPolicy{
+			Request:  configs.HeaderRules{Deny: []string{"cookie", "x-user-id"}},
+			Response: configs.HeaderRules{Allow: []string{"Content-Type", "ETag"}},
+		},
+	}}
+	if err := configs.ValidateRoutes(routes, nil, configs.ServicesConfig{BookServiceURLs: []string{"http://localhost:3001"}}); err != nil {
+		t.Fatalf("ValidateRoutes: %v", err)
+	}
+	route := &routes[0]
+
+	request := http.Header{
+		"Cookie":          {"session=1"},
+		"Accept":          {"application/json"},
+		"X-User-Id":       {"7"},
+		"Connection":      {"close"},
+		"X-Forwarded-For": {"1.2.3.4"},
+	}
+	upstream := http.Header{}
+	copyRequestHeaders(request, upstream, route.RequestHeaders())
+	if upstream.Get("Cookie") != "" || upstream.Get("Connection") != "" || upstream.Get("X-Forwarded-For") != "" {
+		t.Errorf("reddedilen, hop-by-hop veya forwarding header'ı iletildi: %v", upstream)
+	}
+	if upstream.Get("Accept") == "" || upstream.Get("X-User-Id") != "7" {
+		t.Errorf("izinli veya gateway kimlik header'ı iletilmedi: %v", upstream)
+	}
+
+	response := http.Header{
+		"Content-Type":                {"application/json"},
+		"Etag":                        {`"v1"`},
+		"Server":                      {"book-service"},
+		"Access-Control-Allow-Origin": {"*"},
+		"X-Cache":                     {"HIT"},
+		"Connection":                  {"Etag"},
+	}
+	client := http.Header{}
+	copyResponseHeaders(response, client, route.ResponseHeaders())
+	if len(client) != 1 || client.Get("Content-Type") != "application/json" {
+		t.Errorf("istemciye iletilen header'lar = %v, sadece Content-Type kalmalı", client)
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+)
+
+// Health check durumları
+const (
+	HealthUnknown = "UNKNOWN"
+	HealthOK      = "OK"
+	HealthError   = "ERROR"
+	HealthOffline = "OFFLINE"
+)
+
+// InstanceHealth tek bir upstream instance'ının son health check sonucu
+type InstanceHealth struct {
+	URL                  string    `json:"url"`
+	Status               string    `json:"status"`
+	Healthy              bool      `json:"healthy"`
+	LatencyMs            float64   `json:"latency_ms"`
+	ConsecutiveFailures  int       `json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `json:"consecutive_successes"`
+	LastCheck            time.Time `json:"last_check"`
+	LastChange           time.Time `json:"last_change"`
+	LastError            string    `json:"last_error,omitempty"`
+}
+
+// ServiceHealth bir servisin instance'larından derlenen health durumu
+type ServiceHealth struct {
+	Status     string           `json:"status"`
+	LastChange time.Time        `json:"last_change"`
+	Instances  []InstanceHealth `json:"instances"`
+}
+
+// HealthReport tüm servislerin önbellekteki health durumu
+type HealthReport struct {
+	CheckedAt time.Time                `json:"checked_at"`
+	Services  map[string]ServiceHealth `json:"services"`
+}
+
+// Summary servis adı -> durum eşlemesini döner
+func (r HealthReport) Summary() map[string]string {
+	summary := make(map[string]string, len(r.Services))
+	for name, health := range r.Services {
+		summary[name] = health.Status
+	}
+	return summary
+}
+
+// HealthChecker upstream'leri arka planda aktif olarak kontrol eden interface
+type HealthChecker interface {
+	Start(ctx context.Context)
+	CheckNow(ctx context.Context)
+	Report() HealthReport
+}
+
+// ActiveHealthChecker HealthChecker implementasyonu.
+// Tüm instance'lar her turda eşzamanlı ve timeout ile kontrol edilir; art arda hata veren instance'lar
+// load balancer'dan çıkarılır, tekrar art arda başarılı olduklarında geri alınır.
+type ActiveHealthChecker struct {
+	config       configs.HealthCheckConfig
+	loadBalancer LoadBalancer
+	httpClient   *http.Client
+
+	mu        sync.RWMutex
+	instances map[string]map[string]*InstanceHealth
+	services  map[string]*ServiceHealth
+	checkedAt time.Time
+}
+
+// NewHealthChecker yeni aktif health checker oluşturur
+func NewHealthChecker(config configs.HealthCheckConfig, loadBalancer LoadBalancer) HealthChecker {
+	now := time.Now()
+	checker := &ActiveHealthChecker{
+		config:       config,
+		loadBalancer: loadBalancer,
+		httpClient:   &http.Client{Timeout: config.Timeout},
+		instances:    make(map[string]map[string]*InstanceHealth),
+		services:     make(map[string]*ServiceHealth),
+	}
+
+	checker.sync(loadBalancer.Upstreams(), now)
+	return checker
+}
+
+// sync izlenen instance'ları load balancer'daki güncel listeyle (service registry değişiklikleri dahil) eşitler.
+// Çağıran kilidi tutmalıdır.
+func (h *ActiveHealthChecker) sync(upstreams map[string][]string, now time.Time) {
+	for name, urls := range upstreams {
+		instances, ok := h.instances[name]
+		if !ok {
+			instances = make(map[string]*InstanceHealth, len(urls))
+			h.instances[name] = instances
+			h.services[name] = &ServiceHealth{Status: HealthUnknown, LastChange: now}
+		}
+
+		current := make(map[string]bool, len(urls))
+		for _, url := range urls {
+			current[url] = true
+			if _, ok := instances[url]; !ok {
+				instances[url] = &InstanceHealth{
+					URL:        url,
+					Status:     HealthUnknown,
+					Healthy:    true,
+					LastChange: now,
+				}
+			}
+		}
+		for url := range instances {
+			if !current[url] {
+				delete(instances, url)
+			}
+		}
+	}
+	for name, instances := range h.instances {
+		if _, ok := upstreams[name]; !ok {
+			clear(instances)
+		}
+	}
+}
+
+// Start ilk kontrolü hemen yapar, ardından context iptal edilene kadar her interval'da tekrarlar
+func (h *ActiveHealthChecker) Start(ctx context.Context) {
+	h.CheckNow(ctx)
+
+	ticker := time.NewTicker(h.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.CheckNow(ctx)
+		}
+	}
+}
+
+// CheckNow tüm instance'ları eşzamanlı kontrol eder ve sonuçları kaydeder
+func (h *ActiveHealthChecker) CheckNow(ctx context.Context) {
+	upstreams := h.loadBalancer.Upstreams()
+	h.mu.Lock()
+	h.sync(upstreams, time.Now())
+	h.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for name, urls := range upstreams {
+		for _, url := range urls {
+			wg.Add(1)
+			go func(name, url string) {
+				defer wg.Done()
+				status, latency, err := h.probe(ctx, url)
+				h.record(name, url, status, latency, err)
+			}(name, url)
+		}
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	h.checkedAt = time.Now()
+	for name := range h.services {
+		h.aggregate(name)
+	}
+	h.mu.Unlock()
+}
+
+// Report önbellekteki health durumunun kopyasını döner
+func (h *ActiveHealthChecker) Report() HealthReport {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	report := HealthReport{
+		CheckedAt: h.checkedAt,
+		Services:  make(map[string]ServiceHealth, len(h.services)),
+	}
+	for name, service := range h.services {
+		health := *service
+		health.Instances = make([]InstanceHealth, 0, len(h.instances[name]))
+		for _, instance := range h.instances[name] {
+			health.Instances = append(health.Instances, *instance)
+		}
+		sort.Slice(health.Instances, func(i, j int) bool {
+			return health.Instances[i].URL < health.Instances[j].URL
+		})
+		report.Services[name] = health
+	}
+	return report
+}
+
+// probe instance'ın health endpoint'ini timeout ile çağırır
+func (h *ActiveHealthChecker) probe(ctx context.Context, url string) (string, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+h.config.Path, nil)
+	if err != nil {
+		return HealthOffline, 0, err
+	}
+
+	start := time.Now()
+	resp, err := h.httpClient.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return HealthOffline, latency, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return HealthError, latency, fmt.Errorf("beklenmeyen durum kodu: %d", resp.StatusCode)
+	}
+	return HealthOK, latency, nil
+}
+
+// record tek bir kontrol sonucunu kaydeder; eşik aşıldığında instance'ı trafikten çıkarır veya geri alır
+func (h *ActiveHealthChecker) record(name, url, status string, latency time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	instance, ok := h.instances[name][url]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	instance.Status = status
+	instance.LatencyMs = float64(latency.Microseconds()) / 1000
+	instance.LastCheck = now
+	instance.LastError = ""
+
+	if status == HealthOK {
+		instance.ConsecutiveFailures = 0
+		instance.ConsecutiveSuccesses++
+		if !instance.Healthy && instance.ConsecutiveSuccesses >= h.config.HealthyThreshold {
+			instance.Healthy = true
+			instance.LastChange = now
+			h.loadBalancer.SetHealthy(name, url, true)
+			logging.Infof("💚 [%s] Instance tekrar trafiğe alındı: %s", name, url)
+		}
+		return
+	}
+
+	instance.LastError = err.Error()
+	instance.ConsecutiveSuccesses = 0
+	instance.ConsecutiveFailures++
+	if instance.Healthy && instance.ConsecutiveFailures >= h.config.UnhealthyThreshold {
+		instance.Healthy = false
+		instance.LastChange = now
+		h.loadBalancer.SetHealthy(name, url, false)
+		logging.Warnf("💔 [%s] Instance trafikten çıkarıldı (%d art arda hata): %s (%v)", name, instance.ConsecutiveFailures, url, err)
+	}
+}
+
+// aggregate servis durumunu instance'lardan derler. Trafikte ve yanıt veren bir instance varsa servis OK,
+// yanıt veren ama trafikte olmayan (hata dönen veya geri alınmayı bekleyen) instance varsa ERROR kabul edilir.
+// Çağıran kilidi tutmalıdır.
+func (h *ActiveHealthChecker) aggregate(name string) {
+	status := HealthOffline
+	for _, instance := range h.instances[name] {
+		if instance.Status == HealthOK && instance.Healthy {
+			status = HealthOK
+			break
+		}
+		if instance.Status == HealthOK || instance.Status == HealthError {
+			status = HealthError
+		}
+	}
+
+	service := h.services[name]
+	if service.Status != status {
+		if service.Status != HealthUnknown {
+			logging.Infof("🩺 [%s] Servis durumu değişti: %s -> %s", name, service.Status, status)
+		}
+		service.Status = status
+		service.LastChange = h.checkedAt
+	}
+}
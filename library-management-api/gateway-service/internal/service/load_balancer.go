@@ -0,0 +1,483 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gateway-service/configs"
+)
+
+// hashRingReplicas consistent hashing ring'inde her instance için sanal node sayısı
+const hashRingReplicas = 100
+
+// passiveEjectDuration bağlantı hatası alan instance'ın trafikten çıkarıldığı süre
+const passiveEjectDuration = 10 * time.Second
+
+// DefaultInstanceWeight instance'ların başlangıç ağırlığı
+const DefaultInstanceWeight = 1
+
+// MaxInstanceWeight bir instance'a verilebilecek en yüksek ağırlık
+const MaxInstanceWeight = 100
+
+// ErrNoUpstreamInstance upstream için kullanılabilir instance bulunamadığında döner
+var ErrNoUpstreamInstance = errors.New("kullanılabilir upstream instance'ı yok")
+
+// ErrUnknownInstance upstream veya instance URL'i load balancer'da tanımlı olmadığında döner
+var ErrUnknownInstance = errors.New("upstream instance'ı bulunamadı")
+
+// ErrInvalidWeight instance ağırlığı izin verilen aralıkta olmadığında döner
+var ErrInvalidWeight = fmt.Errorf("instance ağırlığı %d-%d arasında olmalı", DefaultInstanceWeight, MaxInstanceWeight)
+
+// Instance tek bir upstream instance'ı
+type Instance struct {
+	URL string
+
+	outstanding    atomic.Int64
+	healthy        atomic.Bool
+	unhealthyUntil atomic.Int64
+	weight         atomic.Int64
+	draining       atomic.Bool
+
+	// current smooth weighted round robin için biriken ağırlık (pool.mu ile korunur)
+	current int64
+}
+
+// InstanceStatus instance'ın anlık durumu
+type InstanceStatus struct {
+	URL         string `json:"url"`
+	Healthy     bool   `json:"healthy"`
+	Draining    bool   `json:"draining"`
+	Weight      int    `json:"weight"`
+	Outstanding int64  `json:"outstanding"`
+}
+
+// Done instance'a gönderilen isteğin tamamlandığını bildirir
+func (i *Instance) Done() {
+	i.outstanding.Add(-1)
+}
+
+// Outstanding instance üzerindeki devam eden istek sayısını döner
+func (i *Instance) Outstanding() int64 {
+	return i.outstanding.Load()
+}
+
+// Weight instance'ın yük dağıtımındaki ağırlığını döner
+func (i *Instance) Weight() int64 {
+	return i.weight.Load()
+}
+
+// Draining instance'ın yeni trafikten çekilip çekilmediğini döner; devam eden istekler tamamlanır
+func (i *Instance) Draining() bool {
+	return i.draining.Load()
+}
+
+// Available instance'ın sağlık durumuna göre trafik alıp alamayacağını kontrol eder
+func (i *Instance) Available() bool {
+	if !i.healthy.Load() {
+		return false
+	}
+	return time.Now().UnixNano() >= i.unhealthyUntil.Load()
+}
+
+// UpstreamPool bir servise ait instance havuzu
+type UpstreamPool struct {
+	Name      string
+	instances []*Instance
+	next      atomic.Uint64
+
+	// mu instance listesini, ağırlıklı round robin durumunu ve instance'lar veya ağırlıklar
+	// değişince yeniden oluşturulan ring'i korur. instances yerinde değiştirilmez, her değişiklikte yenisi atanır.
+	mu   sync.RWMutex
+	ring []ringNode
+}
+
+// ringNode consistent hashing ring'indeki sanal node
+type ringNode struct {
+	hash     uint32
+	instance *Instance
+}
+
+// LoadBalancer upstream instance seçimi interface'i
+type LoadBalancer interface {
+	Pick(upstream string, policy configs.LoadBalancerConfig, r *http.Request) (*Instance, error)
+	ReportFailure(instance *Instance)
+	SetHealthy(upstream, url string, healthy bool)
+	SetDraining(upstream, url string, draining bool) (previous bool, err error)
+	SetWeight(upstream, url string, weight int) (previous int, err error)
+	SyncInstances(upstream string, urls []string) (added, removed []string)
+	Upstreams() map[string][]string
+	Status() map[string][]InstanceStatus
+}
+
+// LoadBalancerImpl LoadBalancer implementasyonu
+type LoadBalancerImpl struct {
+	mu    sync.RWMutex
+	pools map[string]*UpstreamPool
+}
+
+// NewLoadBalancer servis konfigürasyonundan upstream havuzlarını oluşturur
+func NewLoadBalancer(services configs.ServicesConfig) LoadBalancer {
+	lb := &LoadBalancerImpl{
+		pools: make(map[string]*UpstreamPool),
+	}
+	for name, urls := range services.ByName() {
+		lb.pools[name] = newUpstreamPool(name, urls)
+	}
+	return lb
+}
+
+// newUpstreamPool yeni instance havuzu ve hash ring'i oluşturur
+func newUpstreamPool(name string, urls []string) *UpstreamPool {
+	pool := &UpstreamPool{Name: name}
+	for _, url := range urls {
+		pool.instances = append(pool.instances, newInstance(url))
+	}
+	pool.ring = buildRing(pool.instances)
+	return pool
+}
+
+// newInstance varsayılan ağırlıkla sağlıklı yeni instance oluşturur
+func newInstance(url string) *Instance {
+	instance := &Instance{URL: url}
+	instance.healthy.Store(true)
+	instance.weight.Store(DefaultInstanceWeight)
+	return instance
+}
+
+// buildRing instance'ları ağırlıklarıyla orantılı sayıda sanal node ile hash ring'ine yerleştirir
+func buildRing(instances []*Instance) []ringNode {
+	var ring []ringNode
+	for _, instance := range instances {
+		replicas := hashRingReplicas * int(instance.Weight())
+		for r := 0; r < replicas; r++ {
+			ring = append(ring, ringNode{
+				hash:     crc32.ChecksumIEEE([]byte(instance.URL + "#" + strconv.Itoa(r))),
+				instance: instance,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	return ring
+}
+
+// Pick route politikasına göre upstream instance'ı seçer ve outstanding sayacını artırır
+func (lb *LoadBalancerImpl) Pick(upstream string, policy configs.LoadBalancerConfig, r *http.Request) (*Instance, error) {
+	lb.mu.RLock()
+	pool, ok := lb.pools[upstream]
+	lb.mu.RUnlock()
+	if !ok || len(pool.members()) == 0 {
+		return nil, fmt.Errorf("%s: %w", upstream, ErrNoUpstreamInstance)
+	}
+
+	var instance *Instance
+	switch policy.Strategy {
+	case configs.StrategyLeastOutstanding:
+		instance = pool.pickLeastOutstanding()
+	case configs.StrategyConsistentHash:
+		if key := r.Header.Get(policy.HashHeader); key != "" {
+			instance = pool.pickConsistentHash(key)
+		} else {
+			instance = pool.pickRoundRobin()
+		}
+	default:
+		instance = pool.pickRoundRobin()
+	}
+
+	if instance == nil {
+		return nil, fmt.Errorf("%s: %w", upstream, ErrNoUpstreamInstance)
+	}
+
+	instance.outstanding.Add(1)
+	return instance, nil
+}
+
+// ReportFailure bağlantı hatası alan instance'ı kısa süreliğine trafikten çıkarır
+func (lb *LoadBalancerImpl) ReportFailure(instance *Instance) {
+	instance.unhealthyUntil.Store(time.Now().Add(passiveEjectDuration).UnixNano())
+}
+
+// SetHealthy instance'ın sağlık durumunu günceller
+func (lb *LoadBalancerImpl) SetHealthy(upstream, url string, healthy bool) {
+	lb.mu.RLock()
+	pool, ok := lb.pools[upstream]
+	lb.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	for _, instance := range pool.members() {
+		if instance.URL == url {
+			instance.healthy.Store(healthy)
+			if healthy {
+				instance.unhealthyUntil.Store(0)
+			}
+		}
+	}
+}
+
+// SetDraining instance'ı yeni trafikten çeker veya tekrar trafiğe alır; önceki durumu döner
+func (lb *LoadBalancerImpl) SetDraining(upstream, url string, draining bool) (bool, error) {
+	pool, instance, err := lb.lookup(upstream, url)
+	if err != nil {
+		return false, err
+	}
+
+	previous := instance.draining.Swap(draining)
+	if previous != draining {
+		pool.mu.Lock()
+		instance.current = 0
+		pool.mu.Unlock()
+	}
+	return previous, nil
+}
+
+// SetWeight instance'ın ağırlığını değiştirir ve hash ring'ini yeniden oluşturur; önceki ağırlığı döner
+func (lb *LoadBalancerImpl) SetWeight(upstream, url string, weight int) (int, error) {
+	if weight < DefaultInstanceWeight || weight > MaxInstanceWeight {
+		return 0, ErrInvalidWeight
+	}
+	pool, instance, err := lb.lookup(upstream, url)
+	if err != nil {
+		return 0, err
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	previous := instance.weight.Swap(int64(weight))
+	if previous != int64(weight) {
+		for _, other := range pool.instances {
+			other.current = 0
+		}
+		pool.ring = buildRing(pool.instances)
+	}
+	return int(previous), nil
+}
+
+// SyncInstances upstream'in instance listesini verilen URL'lerle eşitler (service registry değişikliklerinde).
+// Listede kalan instance'ların ağırlık, drain ve sağlık durumu korunur; havuz yoksa oluşturulur.
+func (lb *LoadBalancerImpl) SyncInstances(upstream string, urls []string) (added, removed []string) {
+	lb.mu.Lock()
+	pool, ok := lb.pools[upstream]
+	if !ok {
+		pool = newUpstreamPool(upstream, nil)
+		lb.pools[upstream] = pool
+	}
+	lb.mu.Unlock()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	existing := make(map[string]*Instance, len(pool.instances))
+	for _, instance := range pool.instances {
+		existing[instance.URL] = instance
+	}
+
+	instances := make([]*Instance, 0, len(urls))
+	wanted := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		url = strings.TrimSuffix(url, "/")
+		if wanted[url] {
+			continue
+		}
+		wanted[url] = true
+		instance, ok := existing[url]
+		if !ok {
+			instance = newInstance(url)
+			added = append(added, url)
+		}
+		instances = append(instances, instance)
+	}
+	for url := range existing {
+		if !wanted[url] {
+			removed = append(removed, url)
+		}
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		for _, instance := range instances {
+			instance.current = 0
+		}
+		pool.instances = instances
+		pool.ring = buildRing(instances)
+	}
+	return added, removed
+}
+
+// lookup upstream havuzunu ve URL'e ait instance'ı bulur
+func (lb *LoadBalancerImpl) lookup(upstream, url string) (*UpstreamPool, *Instance, error) {
+	lb.mu.RLock()
+	pool, ok := lb.pools[upstream]
+	lb.mu.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: %w", upstream, ErrUnknownInstance)
+	}
+
+	url = strings.TrimSuffix(url, "/")
+	for _, instance := range pool.members() {
+		if instance.URL == url {
+			return pool, instance, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%s %s: %w", upstream, url, ErrUnknownInstance)
+}
+
+// Upstreams servis adı -> instance URL'leri eşlemesini döner
+func (lb *LoadBalancerImpl) Upstreams() map[string][]string {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	result := make(map[string][]string, len(lb.pools))
+	for name, pool := range lb.pools {
+		for _, instance := range pool.members() {
+			result[name] = append(result[name], instance.URL)
+		}
+	}
+	return result
+}
+
+// Status tüm instance'ların anlık durumunu döner
+func (lb *LoadBalancerImpl) Status() map[string][]InstanceStatus {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	result := make(map[string][]InstanceStatus, len(lb.pools))
+	for name, pool := range lb.pools {
+		for _, instance := range pool.members() {
+			result[name] = append(result[name], InstanceStatus{
+				URL:         instance.URL,
+				Healthy:     instance.Available(),
+				Draining:    instance.Draining(),
+				Weight:      int(instance.Weight()),
+				Outstanding: instance.Outstanding(),
+			})
+		}
+	}
+	return result
+}
+
+// members havuzdaki instance'ları döner; dönen slice değiştirilmemelidir
+func (p *UpstreamPool) members() []*Instance {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.instances
+}
+
+// available trafik alabilecek instance'ları döner; drain edilen instance'lar hiçbir zaman seçilmez,
+// kalanların hiçbiri sağlıklı değilse drain edilmemiş tüm instance'lar denenir
+func (p *UpstreamPool) available() []*Instance {
+	instances := p.members()
+	available := make([]*Instance, 0, len(instances))
+	active := make([]*Instance, 0, len(instances))
+	for _, instance := range instances {
+		if instance.Draining() {
+			continue
+		}
+		active = append(active, instance)
+		if instance.Available() {
+			available = append(available, instance)
+		}
+	}
+	if len(available) == 0 {
+		return active
+	}
+	return available
+}
+
+// pickRoundRobin sağlıklı instance'lar arasında ağırlıklarına göre sırayla seçer.
+// Ağırlıklar eşitse basit round robin, değilse smooth weighted round robin kullanılır.
+func (p *UpstreamPool) pickRoundRobin() *Instance {
+	available := p.available()
+	if len(available) == 0 {
+		return nil
+	}
+	if uniformWeights(available) {
+		n := p.next.Add(1)
+		return available[(n-1)%uint64(len(available))]
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var total int64
+	var selected *Instance
+	for _, instance := range available {
+		weight := instance.Weight()
+		instance.current += weight
+		total += weight
+		if selected == nil || instance.current > selected.current {
+			selected = instance
+		}
+	}
+	selected.current -= total
+	return selected
+}
+
+// pickLeastOutstanding ağırlığa oranla en az devam eden isteği olan instance'ı seçer; eşitlikte sırayla dağıtır
+func (p *UpstreamPool) pickLeastOutstanding() *Instance {
+	available := p.available()
+	if len(available) == 0 {
+		return nil
+	}
+	offset := int(p.next.Add(1) % uint64(len(available)))
+
+	var selected *Instance
+	for i := range available {
+		instance := available[(offset+i)%len(available)]
+		// outstanding/weight karşılaştırması bölme yapmadan çapraz çarpımla yapılır
+		if selected == nil || instance.Outstanding()*selected.Weight() < selected.Outstanding()*instance.Weight() {
+			selected = instance
+		}
+	}
+	return selected
+}
+
+// pickConsistentHash anahtarın hash'ine göre ring üzerindeki ilk sağlıklı instance'ı seçer
+func (p *UpstreamPool) pickConsistentHash(key string) *Instance {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.ring) == 0 {
+		return nil
+	}
+
+	hash := crc32.ChecksumIEEE([]byte(key))
+	start := sort.Search(len(p.ring), func(i int) bool {
+		return p.ring[i].hash >= hash
+	})
+
+	var fallback *Instance
+	for i := 0; i < len(p.ring); i++ {
+		node := p.ring[(start+i)%len(p.ring)]
+		if node.instance.Draining() {
+			continue
+		}
+		if node.instance.Available() {
+			return node.instance
+		}
+		if fallback == nil {
+			fallback = node.instance
+		}
+	}
+
+	// Hiçbir instance sağlıklı değilse hash'in düştüğü ilk drain edilmemiş instance'ı kullan
+	return fallback
+}
+
+// uniformWeights tüm instance'ların ağırlığının eşit olup olmadığını kontrol eder
+func uniformWeights(instances []*Instance) bool {
+	for _, instance := range instances[1:] {
+		if instance.Weight() != instances[0].Weight() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"gateway-service/configs"
+)
+
+const testUpstream = "book-service"
+
+func newTestLoadBalancer(urls ...string) LoadBalancer {
+	return NewLoadBalancer(configs.ServicesConfig{BookServiceURLs: urls})
+}
+
+// pickCounts n kez seçim yapar ve instance başına seçim sayısını döner
+func pickCounts(t *testing.T, lb LoadBalancer, policy configs.LoadBalancerConfig, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		instance, err := lb.Pick(testUpstream, policy, httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		counts[instance.URL]++
+		instance.Done()
+	}
+	return counts
+}
+
+func TestLoadBalancerRoundRobin(t *testing.T) {
+	tests := []struct {
+		name      string
+		urls      []string
+		weights   map[string]int
+		draining  []string
+		unhealthy []string
+		picks     int
+		want      map[string]int
+	}{
+		{
+			name:  "eşit ağırlık",
+			urls:  []string{"http://a", "http://b", "http://c"},
+			picks: 6,
+			want:  map[string]int{"http://a": 2, "http://b": 2, "http://c": 2},
+		},
+		{
+			name:    "ağırlıklı",
+			urls:    []string{"http://a", "http://b"},
+			weights: map[string]int{"http://a": 3},
+			picks:   8,
+			want:    map[string]int{"http://a": 6, "http://b": 2},
+		},
+		{
+			name:     "drain edilen instance seçilmez",
+			urls:     []string{"http://a", "http://b"},
+			draining: []string{"http://a"},
+			picks:    4,
+			want:     map[string]int{"http://b": 4},
+		},
+		{
+			name:      "sağlıksız instance seçilmez",
+			urls:      []string{"http://a", "http://b"},
+			unhealthy: []string{"http://b"},
+			picks:     4,
+			want:      map[string]int{"http://a": 4},
+		},
+		{
+			name:      "hepsi sağlıksızsa drain edilmeyenler denenir",
+			urls:      []string{"http://a", "http://b"},
+			draining:  []string{"http://a"},
+			unhealthy: []string{"http://a", "http://b"},
+			picks:     3,
+			want:      map[string]int{"http://b": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := newTestLoadBalancer(tt.urls...)
+			for url, weight := range tt.weights {
+				if _, err := lb.SetWeight(testUpstream, url, weight); err != nil {
+					t.Fatalf("SetWeight: %v", err)
+				}
+			}
+			for _, url := range tt.draining {
+				if _, err := lb.SetDraining(testUpstream, url, true); err != nil {
+					t.Fatalf("SetDraining: %v", err)
+				}
+			}
+			for _, url := range tt.unhealthy {
+				lb.SetHealthy(testUpstream, url, false)
+			}
+
+			got := pickCounts(t, lb, configs.LoadBalancerConfig{Strategy: configs.StrategyRoundRobin}, tt.picks)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("seçimler = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerLeastOutstanding(t *testing.T) {
+	lb := newTestLoadBalancer("http://a", "http://b", "http://c")
+	policy := configs.LoadBalancerConfig{Strategy: configs.StrategyLeastOutstanding}
+	request := httptest.NewRequest("GET", "/", nil)
+
+	// Seçilen instance'lar bırakılmadığı için her seçim en az yüklü instance'a gider
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		instance, err := lb.Pick(testUpstream, policy, request)
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		if seen[instance.URL] {
+			t.Fatalf("%s yükü varken tekrar seçildi", instance.URL)
+		}
+		seen[instance.URL] = true
+	}
+
+	// Ağırlığı yüksek instance oranla daha fazla istek alır
+	if _, err := lb.SetWeight(testUpstream, "http://c", 3); err != nil {
+		t.Fatalf("SetWeight: %v", err)
+	}
+	instance, _ := lb.Pick(testUpstream, policy, request)
+	if instance.URL != "http://c" {
+		t.Errorf("seçilen = %s, beklenen http://c", instance.URL)
+	}
+}
+
+func TestLoadBalancerConsistentHash(t *testing.T) {
+	lb := newTestLoadBalancer("http://a", "http://b", "http://c")
+	policy := configs.LoadBalancerConfig{Strategy: configs.StrategyConsistentHash, HashHeader: "X-User-ID"}
+
+	pick := func(key string) string {
+		request := httptest.NewRequest("GET", "/", nil)
+		if key != "" {
+			request.Header.Set("X-User-ID", key)
+		}
+		instance, err := lb.Pick(testUpstream, policy, request)
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		instance.Done()
+		return instance.URL
+	}
+
+	owners := make(map[string]string)
+	used := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		owners[key] = pick(key)
+		used[owners[key]] = true
+		if again := pick(key); again != owners[key] {
+			t.Fatalf("%s: aynı anahtar farklı instance'a gitti (%s, %s)", key, owners[key], again)
+		}
+	}
+	if len(used) < 2 {
+		t.Errorf("anahtarlar tek instance'a toplandı: %v", used)
+	}
+
+	// Drain edilen instance'ın anahtarları taşınır, diğerlerininki yerinde kalır
+	if _, err := lb.SetDraining(testUpstream, "http://a", true); err != nil {
+		t.Fatalf("SetDraining: %v", err)
+	}
+	for key, owner := range owners {
+		got := pick(key)
+		switch {
+		case got == "http://a":
+			t.Errorf("%s drain edilen instance'a gitti", key)
+		case owner != "http://a" && got != owner:
+			t.Errorf("%s: %s -> %s taşındı, sadece drain edilen instance'ın anahtarları taşınmalı", key, owner, got)
+		}
+	}
+
+	// Header yoksa round robin'e düşülür
+	if got := pick(""); got == "" {
+		t.Error("header'sız istek için instance seçilmedi")
+	}
+}
+
+func TestLoadBalancerInstanceUpdates(t *testing.T) {
+	lb := newTestLoadBalancer("http://a")
+
+	tests := []struct {
+		name    string
+		url     string
+		weight  int
+		wantErr error
+	}{
+		{name: "geçerli ağırlık", url: "http://a", weight: 5},
+		{name: "sondaki / yok sayılır", url: "http://a/", weight: 2},
+		{name: "sıfır ağırlık", url: "http://a", weight: 0, wantErr: ErrInvalidWeight},
+		{name: "üst sınır aşılır", url: "http://a", weight: MaxInstanceWeight + 1, wantErr: ErrInvalidWeight},
+		{name: "bilinmeyen instance", url: "http://b", weight: 2, wantErr: ErrUnknownInstance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lb.SetWeight(testUpstream, tt.url, tt.weight)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("hata = %v, beklenen %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerSyncInstancesKeepsState(t *testing.T) {
+	lb := newTestLoadBalancer("http://a", "http://b")
+	if _, err := lb.SetDraining(testUpstream, "http://a", true); err != nil {
+		t.Fatalf("SetDraining: %v", err)
+	}
+
+	added, removed := lb.SyncInstances(testUpstream, []string{"http://a/", "http://c"})
+	if fmt.Sprint(added) != "[http://c]" || fmt.Sprint(removed) != "[http://b]" {
+		t.Fatalf("added = %v, removed = %v", added, removed)
+	}
+
+	status := make(map[string]InstanceStatus)
+	for _, instance := range lb.Status()[testUpstream] {
+		status[instance.URL] = instance
+	}
+	if len(status) != 2 || !status["http://a"].Draining || status["http://c"].Draining {
+		t.Errorf("senkronizasyon sonrası durum = %+v", status)
+	}
+}
+
+func TestLoadBalancerReportFailure(t *testing.T) {
+	lb := newTestLoadBalancer("http://a", "http://b")
+	policy := configs.LoadBalancerConfig{Strategy: configs.StrategyRoundRobin}
+
+	instance, err := lb.Pick(testUpstream, policy, httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("Pick: %v", err)
+	}
+	instance.Done()
+	lb.ReportFailure(instance)
+
+	got := pickCounts(t, lb, policy, 4)
+	if got[instance.URL] != 0 {
+		t.Errorf("hata bildirilen %s trafik almaya devam etti: %v", instance.URL, got)
+	}
+
+	// Aktif health check instance'ı geri aldığında pasif çıkarma da temizlenir
+	lb.SetHealthy(testUpstream, instance.URL, true)
+	if got := pickCounts(t, lb, policy, 4); got[instance.URL] != 2 {
+		t.Errorf("sağlıklı işaretlenen %s trafiğe dönmedi: %v", instance.URL, got)
+	}
+}
+
+func TestLoadBalancerUnknownUpstream(t *testing.T) {
+	lb := newTestLoadBalancer("http://a")
+	_, err := lb.Pick("library-service", configs.LoadBalancerConfig{}, httptest.NewRequest("GET", "/", nil))
+	if err == nil {
+		t.Error("bilinmeyen upstream için instance seçildi")
+	}
+}
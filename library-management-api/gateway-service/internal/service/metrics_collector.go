@@ -0,0 +1,78 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// circuitStateValues circuit durumlarının metrik değerleri
+var circuitStateValues = map[string]float64{
+	CircuitClosed:   0,
+	CircuitHalfOpen: 1,
+	CircuitOpen:     2,
+}
+
+// UpstreamStateCollector circuit ve instance durumlarını scrape anında Prometheus'a aktarır
+type UpstreamStateCollector struct {
+	loadBalancer LoadBalancer
+	breakers     CircuitBreakerRegistry
+
+	circuitState    *prometheus.Desc
+	instanceHealthy *prometheus.Desc
+	outstanding     *prometheus.Desc
+	weight          *prometheus.Desc
+}
+
+// NewUpstreamStateCollector yeni upstream durum collector'ı oluşturur
+func NewUpstreamStateCollector(loadBalancer LoadBalancer, breakers CircuitBreakerRegistry) *UpstreamStateCollector {
+	return &UpstreamStateCollector{
+		loadBalancer: loadBalancer,
+		breakers:     breakers,
+		circuitState: prometheus.NewDesc(
+			"gateway_circuit_state",
+			"Upstream circuit breaker durumu (0=closed, 1=half_open, 2=open)",
+			[]string{"upstream"}, nil,
+		),
+		instanceHealthy: prometheus.NewDesc(
+			"gateway_upstream_instance_healthy",
+			"Instance'ın trafik alıp almadığı (1=evet, 0=hayır)",
+			[]string{"upstream", "instance"}, nil,
+		),
+		outstanding: prometheus.NewDesc(
+			"gateway_upstream_outstanding_requests",
+			"Instance üzerinde devam eden istek sayısı",
+			[]string{"upstream", "instance"}, nil,
+		),
+		weight: prometheus.NewDesc(
+			"gateway_upstream_instance_weight",
+			"Instance'ın yük dağıtımındaki ağırlığı",
+			[]string{"upstream", "instance"}, nil,
+		),
+	}
+}
+
+// Describe prometheus.Collector implementasyonu
+func (c *UpstreamStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.circuitState
+	ch <- c.instanceHealthy
+	ch <- c.outstanding
+	ch <- c.weight
+}
+
+// Collect prometheus.Collector implementasyonu
+func (c *UpstreamStateCollector) Collect(ch chan<- prometheus.Metric) {
+	for upstream, status := range c.breakers.Status() {
+		ch <- prometheus.MustNewConstMetric(c.circuitState, prometheus.GaugeValue, circuitStateValues[status.State], upstream)
+	}
+
+	for upstream, instances := range c.loadBalancer.Status() {
+		for _, instance := range instances {
+			healthy := 0.0
+			if instance.Healthy && !instance.Draining {
+				healthy = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.instanceHealthy, prometheus.GaugeValue, healthy, upstream, instance.URL)
+			ch <- prometheus.MustNewConstMetric(c.outstanding, prometheus.GaugeValue, float64(instance.Outstanding), upstream, instance.URL)
+			ch <- prometheus.MustNewConstMetric(c.weight, prometheus.GaugeValue, float64(instance.Weight), upstream, instance.URL)
+		}
+	}
+}
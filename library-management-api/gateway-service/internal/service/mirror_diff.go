@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// maxDiffValueLength fark satırlarında gösterilen değerlerin en fazla uzunluğu
+const maxDiffValueLength = 80
+
+// diffBodies iki yanıt body'sini karşılaştırır. İki body de JSON ise farklar alan yolu bazında
+// ($.data[0].title gibi) listelenir, ignore'daki alanlar her seviyede atlanır; değilse byte karşılaştırması yapılır.
+// En fazla max fark döner, fazlası varsa truncated true olur.
+func diffBodies(primary, shadow []byte, ignore map[string]bool, max int) (diffs []string, truncated bool) {
+	var primaryValue, shadowValue interface{}
+	if json.Unmarshal(primary, &primaryValue) != nil || json.Unmarshal(shadow, &shadowValue) != nil {
+		if bytes.Equal(primary, shadow) {
+			return nil, false
+		}
+		return []string{fmt.Sprintf("body farklı (%d byte / shadow %d byte)", len(primary), len(shadow))}, false
+	}
+
+	d := &jsonDiff{ignore: ignore, max: max}
+	d.compare("$", primaryValue, shadowValue)
+	return d.diffs, d.truncated
+}
+
+// jsonDiff iki JSON değeri arasındaki farkları toplar
+type jsonDiff struct {
+	ignore    map[string]bool
+	max       int
+	diffs     []string
+	truncated bool
+}
+
+// add farkı listeye ekler; sınır dolduysa sadece truncated işaretlenir
+func (d *jsonDiff) add(format string, args ...interface{}) {
+	if len(d.diffs) >= d.max {
+		d.truncated = true
+		return
+	}
+	d.diffs = append(d.diffs, fmt.Sprintf(format, args...))
+}
+
+// compare değerleri yapısal olarak karşılaştırır
+func (d *jsonDiff) compare(path string, primary, shadow interface{}) {
+	if d.truncated {
+		return
+	}
+
+	switch p := primary.(type) {
+	case map[string]interface{}:
+		s, ok := shadow.(map[string]interface{})
+		if !ok {
+			d.add("%s: %s != shadow %s", path, diffValue(primary), diffValue(shadow))
+			return
+		}
+		for _, key := range unionKeys(p, s) {
+			if d.ignore[key] {
+				continue
+			}
+			pv, inPrimary := p[key]
+			sv, inShadow := s[key]
+			child := path + "." + key
+			switch {
+			case !inShadow:
+				d.add("%s: shadow yanıtında yok", child)
+			case !inPrimary:
+				d.add("%s: sadece shadow yanıtında var", child)
+			default:
+				d.compare(child, pv, sv)
+			}
+		}
+	case []interface{}:
+		s, ok := shadow.([]interface{})
+		if !ok {
+			d.add("%s: %s != shadow %s", path, diffValue(primary), diffValue(shadow))
+			return
+		}
+		if len(p) != len(s) {
+			d.add("%s: %d eleman != shadow %d eleman", path, len(p), len(s))
+		}
+		for i := 0; i < len(p) && i < len(s); i++ {
+			d.compare(fmt.Sprintf("%s[%d]", path, i), p[i], s[i])
+		}
+	default:
+		if !reflect.DeepEqual(primary, shadow) {
+			d.add("%s: %s != shadow %s", path, diffValue(primary), diffValue(shadow))
+		}
+	}
+}
+
+// unionKeys iki nesnenin anahtarlarını sıralı ve tekrarsız döner
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffValue değeri fark satırı için kısaltılmış JSON olarak döner
+func diffValue(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	if len(encoded) > maxDiffValueLength {
+		return string(encoded[:maxDiffValueLength]) + "…"
+	}
+	return string(encoded)
+}
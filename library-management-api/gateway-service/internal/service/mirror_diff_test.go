@@ -0,0 +1,105 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffBodies(t *testing.T) {
+	tests := []struct {
+		name          string
+		primary       string
+		shadow        string
+		ignore        []string
+		max           int
+		want          []string
+		wantTruncated bool
+	}{
+		{
+			name:    "aynı JSON, farklı alan sırası",
+			primary: `{"id": 1, "title": "Dune"}`,
+			shadow:  `{"title": "Dune", "id": 1}`,
+		},
+		{
+			name:    "değer farkı",
+			primary: `{"data": {"id": 1, "title": "Dune"}}`,
+			shadow:  `{"data": {"id": 1, "title": "Dune Messiah"}}`,
+			want:    []string{`$.data.title: "Dune" != shadow "Dune Messiah"`},
+		},
+		{
+			name:    "eksik ve fazla alanlar",
+			primary: `{"id": 1, "isbn": "x"}`,
+			shadow:  `{"id": 1, "pages": 412}`,
+			want:    []string{"$.isbn: shadow yanıtında yok", "$.pages: sadece shadow yanıtında var"},
+		},
+		{
+			name:    "dizi uzunluğu ve eleman farkı",
+			primary: `{"data": [{"id": 1}, {"id": 2}]}`,
+			shadow:  `{"data": [{"id": 3}]}`,
+			want:    []string{"$.data: 2 eleman != shadow 1 eleman", "$.data[0].id: 1 != shadow 3"},
+		},
+		{
+			name:    "tip farkı",
+			primary: `{"total": 3}`,
+			shadow:  `{"total": "3"}`,
+			want:    []string{`$.total: 3 != shadow "3"`},
+		},
+		{
+			name:    "yok sayılan alanlar her seviyede atlanır",
+			primary: `{"timestamp": 1, "data": [{"id": 1, "timestamp": 2}]}`,
+			shadow:  `{"timestamp": 9, "data": [{"id": 1, "timestamp": 8}]}`,
+			ignore:  []string{"timestamp"},
+		},
+		{
+			name:          "fark sınırı",
+			primary:       `{"a": 1, "b": 2, "c": 3}`,
+			shadow:        `{"a": 0, "b": 0, "c": 0}`,
+			max:           2,
+			want:          []string{"$.a: 1 != shadow 0", "$.b: 2 != shadow 0"},
+			wantTruncated: true,
+		},
+		{
+			name:    "JSON olmayan aynı body",
+			primary: "OK",
+			shadow:  "OK",
+		},
+		{
+			name:    "JSON olmayan farklı body",
+			primary: "<html>hata</html>",
+			shadow:  `{"ok": true}`,
+			want:    []string{"body farklı (17 byte / shadow 12 byte)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ignore := make(map[string]bool)
+			for _, field := range tt.ignore {
+				ignore[field] = true
+			}
+			max := tt.max
+			if max == 0 {
+				max = 20
+			}
+
+			diffs, truncated := diffBodies([]byte(tt.primary), []byte(tt.shadow), ignore, max)
+			if strings.Join(diffs, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("farklar:\n%s\nbeklenen:\n%s", strings.Join(diffs, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, beklenen %v", truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestDiffValueTruncates(t *testing.T) {
+	long := strings.Repeat("a", 200)
+	got := diffValue(long)
+	if !strings.HasSuffix(got, "…") || len(got) != maxDiffValueLength+len("…") {
+		t.Errorf("uzun değer kısaltılmadı: %d karakter", len(got))
+	}
+	if got := diffValue(map[string]interface{}{"id": 1.0}); got != `{"id":1}` {
+		t.Errorf("diffValue = %s", got)
+	}
+}
@@ -1,178 +1,619 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/metrics"
+	"gateway-service/internal/requestid"
+	"gateway-service/internal/tracing"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// statusClientClosedRequest istemci bağlantıyı kapattığında kullanılan (nginx uyumlu) durum kodu
+const statusClientClosedRequest = 499
+
+// streamBufferSize body kopyalanırken kullanılan buffer boyutu
+const streamBufferSize = 32 * 1024
+
+// maxFetchBodyBytes gateway'in kendi adına okuduğu upstream yanıtları için üst sınır
+const maxFetchBodyBytes = 4 << 20
+
+// errProxyRequest upstream isteği oluşturulamadığında döner
+var errProxyRequest = errors.New("proxy isteği oluşturulamadı")
+
+// errFetchBodyTooLarge Fetch ile okunan yanıt maxFetchBodyBytes'ı aştığında döner
+var errFetchBodyTooLarge = errors.New("upstream yanıtı çok büyük")
+
 // ProxyService proxy iş mantığı interface'i
 type ProxyService interface {
-	ProxyRequest(c *gin.Context, targetURL, serviceName string)
-	CheckServiceHealth(serviceURL string) string
-	CheckAllServicesHealth(services map[string]string) map[string]string
+	ProxyRequest(c *gin.Context, route *configs.RouteConfig)
+	Fetch(ctx context.Context, upstream, path string, header http.Header) (*UpstreamResponse, error)
+}
+
+// UpstreamResponse gateway'in kendi adına yaptığı upstream çağrısının belleğe okunmuş yanıtı
+type UpstreamResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
 }
 
 // ProxyServiceImpl ProxyService implementasyonu
 type ProxyServiceImpl struct {
-	httpClient *http.Client
+	httpClient   *http.Client
+	loadBalancer LoadBalancer
+	breakers     CircuitBreakerRegistry
+	retryPolicy  *RetryPolicy
+	metrics      *metrics.UpstreamMetrics
+	forwarder    *forwarder
+	config       configs.ProxyConfig
 }
 
-// NewProxyService yeni proxy service oluşturur
-func NewProxyService() ProxyService {
+// NewProxyService yeni proxy service oluşturur; trustedProxies gelen X-Forwarded-For zincirine güvenilecek proxy'lerdir.
+// config route'ların ezmediği timeout ve body sınırlarının varsayılanlarıdır.
+func NewProxyService(loadBalancer LoadBalancer, breakers CircuitBreakerRegistry, retryPolicy *RetryPolicy, upstreamMetrics *metrics.UpstreamMetrics, trustedProxies []string, config configs.ProxyConfig) ProxyService {
 	return &ProxyServiceImpl{
-		httpClient: &http.Client{},
+		httpClient:   &http.Client{Transport: tracing.Transport(newTransport(config.ConnectTimeout))},
+		loadBalancer: loadBalancer,
+		breakers:     breakers,
+		retryPolicy:  retryPolicy,
+		metrics:      upstreamMetrics,
+		forwarder:    newForwarder(trustedProxies),
+		config:       config,
 	}
 }
 
-// ProxyRequest HTTP isteğini hedef servise yönlendirir
-func (s *ProxyServiceImpl) ProxyRequest(c *gin.Context, targetURL, serviceName string) {
+// ProxyRequest HTTP isteğini hedef servise yönlendirir.
+// İstek ve yanıt body'leri bellekte biriktirilmeden akış (stream) olarak aktarılır,
+// istemcinin context'i upstream isteğine taşınır; böylece iptal edilen istekler upstream'de de sonlanır.
+// Idempotent istekler bağlantı hatalarında ve yapılandırılmış 5xx kodlarında backoff ile tekrar denenir.
+func (s *ProxyServiceImpl) ProxyRequest(c *gin.Context, route *configs.RouteConfig) {
+	serviceName := route.Upstream
+
 	// Orijinal path'i al
 	originalPath := c.Request.URL.Path
-	
-	// Tüm servisler tutarlı şekilde /api prefix'i kullanıyor
-	targetPath := originalPath
-	
+
+	// Versiyon segmentini çıkar ve route'un strip/rewrite kurallarını uygula
+	targetPath := route.RewritePath(originalPath)
+
 	// Query parametrelerini ekle
 	if c.Request.URL.RawQuery != "" {
 		targetPath += "?" + c.Request.URL.RawQuery
 	}
 
-	// Hedef URL'yi oluştur
-	fullTargetURL := targetURL + targetPath
+	// İstek satırı/header ve body boyutlarını route sınırlarına göre kontrol et
+	limits := route.Limits(s.config)
+	if !s.checkRequestSize(c, route, limits) {
+		return
+	}
 
-	log.Printf("🔄 [%s] %s %s -> %s", 
-		serviceName, 
-		c.Request.Method, 
-		originalPath, 
-		fullTargetURL)
+	// İstemci context'i iptal bilgisini taşır, route'un toplam timeout'u üzerine eklenir
+	deadline := newRequestDeadline(c.Request.Context(), limits.Timeout)
+	defer deadline.stop()
+	ctx := deadline.ctx
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("gateway.upstream", serviceName))
+	timeouts := upstreamTimeouts{connect: limits.ConnectTimeout, responseHeader: limits.ResponseHeaderTimeout}
 
-	// HTTP isteği oluştur
-	req, err := http.NewRequest(c.Request.Method, fullTargetURL, c.Request.Body)
-	if err != nil {
-		log.Printf("❌ [%s] İstek oluşturma hatası: %v", serviceName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "PROXY_REQUEST_ERROR",
-				"message": "Proxy isteği oluşturulamadı",
-				"service": serviceName,
-			},
-		})
-		return
+	// Tekrarlanabilir isteklerde body tekrar gönderilebilmesi için belleğe alınır
+	var bufferedBody []byte
+	var streamBody io.Reader
+	retryable := s.retryPolicy.IsRetryable(c.Request)
+	if retryable {
+		var err error
+		bufferedBody, streamBody, retryable, err = s.retryPolicy.BufferBody(c.Request)
+		if err != nil {
+			if tooLarge(err) {
+				s.respondBodyTooLarge(c, route, limits.MaxBodyBytes)
+				return
+			}
+			requestid.Errorf(ctx, "❌ [%s] İstek body'si okunamadı: %v", serviceName, err)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":       "REQUEST_BODY_ERROR",
+					"message":    "İstek body'si okunamadı",
+					"service":    serviceName,
+					"request_id": requestid.Get(c),
+				},
+			})
+			return
+		}
 	}
+	s.retryPolicy.Deposit(serviceName)
 
-	// Header'ları kopyala (önemli olanları)
-	s.copyHeaders(c.Request.Header, req.Header)
+	var resp *http.Response
+	var instance *Instance
+	var err error
+	retries := 0
+	for {
+		body := streamBody
+		if bufferedBody != nil {
+			body = bytes.NewReader(bufferedBody)
+		} else if body == nil && !retryable {
+			body = s.requestBody(c.Request)
+		}
+
+		resp, instance, err = s.sendUpstream(ctx, c, route, targetPath, body, bufferedBody != nil, timeouts)
+
+		if !retryable || !s.shouldRetry(ctx, resp, err) || !s.retryPolicy.Allow(serviceName, retries) {
+			break
+		}
+
+		// Yeniden denenecek yanıtı serbest bırak
+		if resp != nil {
+			requestid.Warnf(ctx, "🔁 [%s] %s %s -> %d, tekrar deneniyor (%d)", serviceName, c.Request.Method, originalPath, resp.StatusCode, retries+1)
+			io.Copy(io.Discard, io.LimitReader(resp.Body, streamBufferSize))
+			resp.Body.Close()
+		} else {
+			requestid.Warnf(ctx, "🔁 [%s] %s %s -> %v, tekrar deneniyor (%d)", serviceName, c.Request.Method, originalPath, err, retries+1)
+		}
+		if instance != nil {
+			instance.Done()
+		}
+		s.metrics.Retry(serviceName)
+		trace.SpanFromContext(ctx).AddEvent("upstream.retry", trace.WithAttributes(attribute.Int("retry", retries+1)))
+
+		if !s.retryPolicy.Wait(ctx, retries) {
+			resp, instance, err = nil, nil, ctx.Err()
+			break
+		}
+		retries++
+	}
+	if instance != nil {
+		defer instance.Done()
+	}
+
+	if retries > 0 {
+		c.Header("X-Retry-Count", strconv.Itoa(retries))
+	}
 
-	// İsteği gönder
-	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		log.Printf("❌ [%s] Servis bağlantı hatası: %v", serviceName, err)
-		c.JSON(http.StatusBadGateway, gin.H{
-			"error": gin.H{
-				"code":    "SERVICE_UNAVAILABLE",
-				"message": fmt.Sprintf("%s servisi kullanılamıyor", serviceName),
-				"service": serviceName,
-				"details": err.Error(),
-			},
-		})
+		s.respondUpstreamError(c, route, deadline.wrap(err))
 		return
 	}
 	defer resp.Body.Close()
 
-	// Yanıtı oku
-	body, err := io.ReadAll(resp.Body)
+	// WebSocket: upstream protokol değişimini kabul ettiyse bağlantılar arasında tünel kurulur
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		s.tunnel(c, route, resp, deadline, limits.IdleTimeout)
+		return
+	}
+
+	// Server-Sent Events: toplam timeout yerine idle timeout uygulanır, her event hemen iletilir
+	var touch func()
+	if isEventStream(resp.Header) {
+		touch = deadline.idle(limits.IdleTimeout)
+	}
+
+	// Response header'larını kopyala (hop-by-hop ve route'un reddettikleri hariç)
+	copyResponseHeaders(resp.Header, c.Writer.Header(), route.ResponseHeaders())
+	if resp.ContentLength >= 0 {
+		c.Writer.Header().Set("Content-Length", strconv.FormatInt(resp.ContentLength, 10))
+	} else {
+		c.Writer.Header().Del("Content-Length")
+	}
+
+	// Upstream trailer'larını önceden duyur, body sonrası değerleri yazılacak
+	for key := range resp.Trailer {
+		c.Writer.Header().Add("Trailer", key)
+	}
+
+	c.Status(resp.StatusCode)
+	c.Writer.WriteHeaderNow()
+
+	// Yanıtı akış olarak gönder
+	written, err := s.streamResponseBody(c.Writer, resp, touch)
 	if err != nil {
-		log.Printf("❌ [%s] Yanıt okuma hatası: %v", serviceName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "RESPONSE_READ_ERROR",
-				"message": "Servis yanıtı okunamadı",
-				"service": serviceName,
-			},
-		})
+		// Header'lar gönderildiği için hata envelope'u yazılamaz, sadece logla
+		requestid.Errorf(ctx, "❌ [%s] Yanıt aktarım hatası (%d bytes sonra): %v", serviceName, written, deadline.wrap(err))
+		c.Abort()
 		return
 	}
 
-	// Response header'larını kopyala
-	s.copyResponseHeaders(resp.Header, c.Writer.Header())
+	// Trailer değerlerini yaz
+	for key, values := range resp.Trailer {
+		for _, value := range values {
+			c.Writer.Header().Add(key, value)
+		}
+	}
 
 	// Başarılı proxy logla
-	log.Printf("✅ [%s] %s %s -> %d (%d bytes)", 
-		serviceName, 
-		c.Request.Method, 
-		originalPath, 
+	requestid.Printf(ctx, "✅ [%s] %s %s -> %d (%d bytes)",
+		serviceName,
+		c.Request.Method,
+		originalPath,
 		resp.StatusCode,
-		len(body))
-
-	// Yanıtı gönder
-	c.Data(resp.StatusCode, resp.Header.Get("Content-Type"), body)
-}
-
-// copyHeaders önemli header'ları kopyalar
-func (s *ProxyServiceImpl) copyHeaders(src, dst http.Header) {
-	// Kritik header'ları kopyala
-	importantHeaders := []string{
-		"Authorization",
-		"Content-Type",
-		"Accept",
-		"User-Agent",
-		"X-Forwarded-For",
-		"X-Real-IP",
-	}
-	
-	for _, header := range importantHeaders {
-		if values := src[header]; len(values) > 0 {
-			for _, value := range values {
-				dst.Add(header, value)
+		written)
+}
+
+// sendUpstream circuit breaker ve load balancer üzerinden tek bir upstream denemesi yapar.
+// Dönen instance nil değilse isteğin işi bittiğinde Done çağrılmalıdır.
+func (s *ProxyServiceImpl) sendUpstream(ctx context.Context, c *gin.Context, route *configs.RouteConfig, targetPath string, body io.Reader, replayable bool, timeouts upstreamTimeouts) (*http.Response, *Instance, error) {
+	serviceName := route.Upstream
+
+	// Circuit açıksa upstream'e gitmeden hızlıca reddet
+	recordOutcome, err := s.breakers.Allow(serviceName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Route politikasına göre upstream instance'ı seç
+	instance, err := s.loadBalancer.Pick(serviceName, route.LoadBalancer, c.Request)
+	if err != nil {
+		recordOutcome(OutcomeIgnored)
+		return nil, nil, err
+	}
+
+	// Hedef URL'yi oluştur
+	fullTargetURL := instance.URL + targetPath
+
+	requestid.Debugf(ctx, "🔄 [%s] %s %s -> %s",
+		serviceName,
+		c.Request.Method,
+		c.Request.URL.Path,
+		fullTargetURL)
+
+	// HTTP isteği oluştur
+	req, err := http.NewRequestWithContext(ctx, c.Request.Method, fullTargetURL, body)
+	if err != nil {
+		recordOutcome(OutcomeIgnored)
+		instance.Done()
+		return nil, nil, fmt.Errorf("%w: %v", errProxyRequest, err)
+	}
+
+	// Body uzunluğunu ve chunked/trailer bilgisini koru (bellekteki body'nin uzunluğu zaten bilinir)
+	if !replayable {
+		req.ContentLength = c.Request.ContentLength
+		req.TransferEncoding = c.Request.TransferEncoding
+		req.Trailer = c.Request.Trailer
+	}
+
+	// İstemci header'larını route politikasına göre kopyala, forwarding header'larını ekle
+	copyRequestHeaders(c.Request.Header, req.Header, route.RequestHeaders())
+	s.forwarder.apply(c, req.Header)
+
+	// Upgrade hop-by-hop olduğundan kopyalanmaz; WebSocket isteklerinde upstream'e yeniden eklenir
+	if protocol := upgradeProtocol(c.Request.Header); protocol != "" {
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", protocol)
+	}
+
+	// Upstream'in yanıt şeklini seçebilmesi için istenen API versiyonunu bildir
+	if version := route.APIVersion(); version != nil {
+		req.Header.Set(configs.HeaderAPIVersion, version.Name)
+	}
+
+	// İsteği gönder
+	start := time.Now()
+	resp, err := s.do(req, timeouts)
+	if err != nil {
+		s.metrics.ObserveAttempt(serviceName, "error", time.Since(start))
+		if errors.Is(c.Request.Context().Err(), context.Canceled) || tooLarge(err) {
+			// İstemci iptali ve sınırı aşan istek body'si upstream'in sağlığıyla ilgili değildir
+			recordOutcome(OutcomeIgnored)
+		} else {
+			recordOutcome(OutcomeFailure)
+			// Yanıt vermekte geciken instance erişilebilirdir; sadece bağlantı hataları bildirilir
+			if !isTimeout(err) || errors.Is(err, errConnectTimeout) {
+				requestid.Errorf(ctx, "❌ [%s] Servis bağlantı hatası (%s): %v", serviceName, instance.URL, err)
+				s.loadBalancer.ReportFailure(instance)
 			}
 		}
+		instance.Done()
+		return nil, nil, err
 	}
+
+	s.metrics.ObserveAttempt(serviceName, strconv.Itoa(resp.StatusCode), time.Since(start))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		recordOutcome(OutcomeFailure)
+	} else {
+		recordOutcome(OutcomeSuccess)
+	}
+
+	return resp, instance, nil
 }
 
-// copyResponseHeaders yanıt header'larını kopyalar
-func (s *ProxyServiceImpl) copyResponseHeaders(src, dst http.Header) {
-	// Response header'larını kopyala
-	responseHeaders := []string{
-		"Content-Type",
-		"Cache-Control",
-		"Expires",
-		"Last-Modified",
-		"ETag",
+// Fetch upstream servise circuit breaker ve load balancer üzerinden GET isteği yapar ve yanıtı belleğe okur.
+// Gateway'in birden fazla servisi kendisi çağırıp yanıtları birleştirdiği durumlar için kullanılır; retry yapılmaz.
+// header'daki istemci header'larından sadece kimlik, dil ve istek ID'si upstream'e gönderilir.
+func (s *ProxyServiceImpl) Fetch(ctx context.Context, upstream, path string, header http.Header) (*UpstreamResponse, error) {
+	resp, err := s.fetch(ctx, upstream, path, header)
+	if err != nil {
+		s.metrics.Error(upstream, UpstreamErrorReason(ctx, err))
+	}
+	return resp, err
+}
+
+// fetch Fetch'in tek upstream denemesi
+func (s *ProxyServiceImpl) fetch(ctx context.Context, upstream, path string, header http.Header) (*UpstreamResponse, error) {
+	recordOutcome, err := s.breakers.Allow(upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		recordOutcome(OutcomeIgnored)
+		return nil, fmt.Errorf("%w: %v", errProxyRequest, err)
 	}
-	
-	for _, header := range responseHeaders {
-		if values := src[header]; len(values) > 0 {
-			for _, value := range values {
-				dst.Set(header, value)
+	copyFetchHeaders(header, req.Header)
+	requestid.Inject(ctx, req)
+
+	instance, err := s.loadBalancer.Pick(upstream, configs.LoadBalancerConfig{}, req)
+	if err != nil {
+		recordOutcome(OutcomeIgnored)
+		return nil, err
+	}
+	defer instance.Done()
+
+	target, err := url.Parse(instance.URL + path)
+	if err != nil {
+		recordOutcome(OutcomeIgnored)
+		return nil, fmt.Errorf("%w: %v", errProxyRequest, err)
+	}
+	req.URL = target
+	req.Host = target.Host
+
+	start := time.Now()
+	resp, err := s.do(req, upstreamTimeouts{connect: s.config.ConnectTimeout, responseHeader: s.config.ResponseHeaderTimeout})
+	if err != nil {
+		s.metrics.ObserveAttempt(upstream, "error", time.Since(start))
+		if errors.Is(ctx.Err(), context.Canceled) {
+			recordOutcome(OutcomeIgnored)
+		} else {
+			recordOutcome(OutcomeFailure)
+			if !isTimeout(err) || errors.Is(err, errConnectTimeout) {
+				requestid.Errorf(ctx, "❌ [%s] Servis bağlantı hatası (%s): %v", upstream, instance.URL, err)
+				s.loadBalancer.ReportFailure(instance)
 			}
 		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchBodyBytes+1))
+	s.metrics.ObserveAttempt(upstream, strconv.Itoa(resp.StatusCode), time.Since(start))
+	if resp.StatusCode >= http.StatusInternalServerError || err != nil {
+		recordOutcome(OutcomeFailure)
+	} else {
+		recordOutcome(OutcomeSuccess)
+	}
+	if err != nil {
+		return nil, err
 	}
+	if len(body) > maxFetchBodyBytes {
+		return nil, fmt.Errorf("%w: %s%s", errFetchBodyTooLarge, upstream, path)
+	}
+
+	return &UpstreamResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
 }
 
-// CheckServiceHealth tek servisin health durumunu kontrol eder
-func (s *ProxyServiceImpl) CheckServiceHealth(serviceURL string) string {
-	resp, err := s.httpClient.Get(serviceURL + "/health")
+// UpstreamErrorReason upstream çağrısı hatasını metriklerde kullanılan nedenlerden birine eşler
+func UpstreamErrorReason(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return metrics.ReasonCanceled
+	case errors.Is(err, ErrCircuitOpen):
+		return metrics.ReasonCircuitOpen
+	case errors.Is(err, ErrNoUpstreamInstance):
+		return metrics.ReasonNoInstance
+	case errors.Is(err, errProxyRequest):
+		return metrics.ReasonRequest
+	case isTimeout(err):
+		return metrics.ReasonTimeout
+	default:
+		return metrics.ReasonConnection
+	}
+}
+
+// shouldRetry upstream sonucunun tekrar denenip denenmeyeceğini belirler
+func (s *ProxyServiceImpl) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
 	if err != nil {
-		return "OFFLINE"
+		return s.retryPolicy.RetryableError(ctx, err)
 	}
-	defer resp.Body.Close()
+	return s.retryPolicy.RetryableStatus(resp.StatusCode)
+}
+
+// respondUpstreamError upstream denemesinin hatasını uygun envelope ile istemciye döner
+func (s *ProxyServiceImpl) respondUpstreamError(c *gin.Context, route *configs.RouteConfig, err error) {
+	serviceName := route.Upstream
+	originalPath := c.Request.URL.Path
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		s.recordError(ctx, serviceName, metrics.ReasonCanceled, err)
+		requestid.Warnf(ctx, "⚠️ [%s] İstemci isteği iptal etti: %s %s", serviceName, c.Request.Method, originalPath)
+		c.AbortWithStatus(statusClientClosedRequest)
+
+	case errors.Is(err, ErrCircuitOpen):
+		s.recordError(ctx, serviceName, metrics.ReasonCircuitOpen, err)
+		retryAfter := s.breakers.RetryAfter(serviceName)
+		requestid.Warnf(ctx, "🔴 [%s] Circuit açık, istek reddedildi: %s %s", serviceName, c.Request.Method, originalPath)
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":       "CIRCUIT_OPEN",
+				"message":    fmt.Sprintf("%s servisi geçici olarak devre dışı", serviceName),
+				"service":    serviceName,
+				"request_id": requestid.Get(c),
+			},
+		})
+
+	case errors.Is(err, ErrNoUpstreamInstance):
+		s.recordError(ctx, serviceName, metrics.ReasonNoInstance, err)
+		requestid.Errorf(ctx, "❌ [%s] Instance seçilemedi: %v", serviceName, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":       "NO_UPSTREAM_AVAILABLE",
+				"message":    fmt.Sprintf("%s için kullanılabilir instance yok", serviceName),
+				"service":    serviceName,
+				"request_id": requestid.Get(c),
+			},
+		})
 
-	if resp.StatusCode == http.StatusOK {
-		return "OK"
+	case errors.Is(err, errProxyRequest):
+		s.recordError(ctx, serviceName, metrics.ReasonRequest, err)
+		requestid.Errorf(ctx, "❌ [%s] İstek oluşturma hatası: %v", serviceName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":       "PROXY_REQUEST_ERROR",
+				"message":    "Proxy isteği oluşturulamadı",
+				"service":    serviceName,
+				"request_id": requestid.Get(c),
+			},
+		})
+
+	case tooLarge(err):
+		s.respondBodyTooLarge(c, route, route.Limits(s.config).MaxBodyBytes)
+
+	case isTimeout(err):
+		s.recordError(ctx, serviceName, metrics.ReasonTimeout, err)
+		phase := timeoutPhase(err)
+		requestid.Warnf(ctx, "⏱️ [%s] Upstream zaman aşımı (%s): %s %s: %v", serviceName, phase, c.Request.Method, originalPath, err)
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"error": gin.H{
+				"code":       "UPSTREAM_TIMEOUT",
+				"message":    fmt.Sprintf("%s servisi zamanında yanıt vermedi", serviceName),
+				"service":    serviceName,
+				"timeout":    phase,
+				"request_id": requestid.Get(c),
+			},
+		})
+
+	default:
+		s.recordError(ctx, serviceName, metrics.ReasonConnection, err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":       "SERVICE_UNAVAILABLE",
+				"message":    fmt.Sprintf("%s servisi kullanılamıyor", serviceName),
+				"service":    serviceName,
+				"request_id": requestid.Get(c),
+				"details":    err.Error(),
+			},
+		})
+	}
+}
+
+// checkRequestSize istek satırı/header ve body boyutunu route sınırlarıyla karşılaştırır, aşılırsa 431/413 döner.
+// Uzunluğu bilinmeyen body'ler okunurken sınırlanır; aşım upstream'e gönderim sırasında 413 olarak raporlanır.
+func (s *ProxyServiceImpl) checkRequestSize(c *gin.Context, route *configs.RouteConfig, limits configs.ProxyConfig) bool {
+	if route.MaxHeaderBytes > 0 {
+		if size := requestHeaderBytes(c.Request); size > route.MaxHeaderBytes {
+			requestid.Warnf(c.Request.Context(), "⚠️ [%s] İstek header'ları çok büyük (%d > %d bytes): %s", route.Upstream, size, route.MaxHeaderBytes, c.Request.URL.Path)
+			c.JSON(http.StatusRequestHeaderFieldsTooLarge, gin.H{
+				"error": gin.H{
+					"code":       "REQUEST_HEADERS_TOO_LARGE",
+					"message":    fmt.Sprintf("İstek header'ları en fazla %d byte olabilir", route.MaxHeaderBytes),
+					"service":    route.Upstream,
+					"request_id": requestid.Get(c),
+				},
+			})
+			return false
+		}
+	}
+
+	if c.Request.ContentLength > limits.MaxBodyBytes {
+		s.respondBodyTooLarge(c, route, limits.MaxBodyBytes)
+		return false
+	}
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBodyBytes)
 	}
-	return "ERROR"
+	return true
 }
 
-// CheckAllServicesHealth tüm servislerin health durumunu kontrol eder
-func (s *ProxyServiceImpl) CheckAllServicesHealth(services map[string]string) map[string]string {
-	results := make(map[string]string)
-	for serviceName, serviceURL := range services {
-		status := s.CheckServiceHealth(serviceURL)
-		results[serviceName] = status
-		log.Printf("🩺 [%s] Health Check: %s", serviceName, status)
+// respondBodyTooLarge sınırı aşan istek body'si için 413 döner
+func (s *ProxyServiceImpl) respondBodyTooLarge(c *gin.Context, route *configs.RouteConfig, limit int64) {
+	requestid.Warnf(c.Request.Context(), "⚠️ [%s] İstek body'si çok büyük (sınır %d bytes): %s %s", route.Upstream, limit, c.Request.Method, c.Request.URL.Path)
+	// Okunmamış body kaldığından bağlantı yeniden kullanılmaz
+	c.Header("Connection", "close")
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": gin.H{
+			"code":       "REQUEST_BODY_TOO_LARGE",
+			"message":    fmt.Sprintf("İstek body'si en fazla %d byte olabilir", limit),
+			"service":    route.Upstream,
+			"request_id": requestid.Get(c),
+		},
+	})
+}
+
+// tooLarge hatanın istek body sınırının aşılmasından kaynaklanıp kaynaklanmadığını döner
+func tooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// timeoutPhase timeout hatasının hangi aşamada oluştuğunu döner
+func timeoutPhase(err error) string {
+	switch {
+	case errors.Is(err, errConnectTimeout):
+		return "connect"
+	case errors.Is(err, errResponseHeaderTimeout):
+		return "response_header"
+	default:
+		return "total"
 	}
-	return results
-} 
\ No newline at end of file
+}
+
+// recordError istemciye dönen upstream hatasını metriklere ve isteğin span'ine işler
+func (s *ProxyServiceImpl) recordError(ctx context.Context, serviceName, reason string, err error) {
+	s.metrics.Error(serviceName, reason)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("gateway.upstream.error", reason))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, reason)
+}
+
+// requestBody upstream'e gönderilecek body'yi döner, body yoksa http.NoBody kullanılır
+func (s *ProxyServiceImpl) requestBody(r *http.Request) io.Reader {
+	if r.Body == nil || (r.ContentLength == 0 && len(r.TransferEncoding) == 0) {
+		return http.NoBody
+	}
+	return r.Body
+}
+
+// streamResponseBody upstream yanıtını istemciye kopyalar.
+// Uzunluğu bilinmeyen (chunked/streaming) yanıtlarda her parçadan sonra flush yapılır.
+// touch nil değilse her okunan parçada çağrılarak idle timeout yenilenir.
+func (s *ProxyServiceImpl) streamResponseBody(w gin.ResponseWriter, resp *http.Response, touch func()) (int64, error) {
+	flush := resp.ContentLength < 0
+	buf := make([]byte, streamBufferSize)
+
+	var written int64
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if touch != nil {
+				touch()
+			}
+			m, writeErr := w.Write(buf[:n])
+			written += int64(m)
+			if writeErr != nil {
+				return written, writeErr
+			}
+			if flush {
+				w.Flush()
+			}
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimitSweepInterval boşta kalan bucket'ların temizlenme aralığı
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitResult token bucket'tan token alma denemesinin sonucu
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// RateLimitStore rate limit bucket'larını tutan store interface'i.
+// Şu an bellek içi implementasyon kullanılıyor; birden fazla gateway instance'ı için paylaşımlı store (ör. Redis) eklenebilir.
+// Store'a ulaşılamazsa hata döner; isteğin geçip geçmeyeceğine middleware RATE_LIMIT_FAIL_OPEN ile karar verir.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, rate float64, burst int) (RateLimitResult, error)
+}
+
+// tokenBucket tek anahtar için token bucket durumu
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time // bucket'ın kendi hızıyla tamamen dolacağı an; bu andan sonra silinmesi limiti değiştirmez
+}
+
+// MemoryRateLimitStore bellek içi RateLimitStore implementasyonu
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore yeni bellek içi rate limit store oluşturur
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Take anahtarın bucket'ından bir token almaya çalışır; bellek içi store hata döndürmez
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, rate float64, burst int) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(burst), last: now}
+		s.buckets[key] = bucket
+	}
+
+	// Geçen süre kadar token ekle
+	bucket.tokens = math.Min(float64(burst), bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+
+	result := RateLimitResult{Limit: burst}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / rate)
+	}
+
+	result.Remaining = int(bucket.tokens)
+	result.Reset = secondsToDuration((float64(burst) - bucket.tokens) / rate)
+	bucket.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep kendi hızıyla tamamen dolmuş ve en az bir sweep aralığıdır kullanılmayan bucket'ları siler.
+// Her bucket kendi grubunun dolma süresine göre değerlendirilir; yarı boş bucket'lar silinip dolu olarak geri gelmez.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.full) && now.Sub(bucket.last) > rateLimitSweepInterval {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// secondsToDuration ondalıklı saniyeyi time.Duration'a çevirir
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	tests := []struct {
+		name          string
+		rate          float64
+		burst         int
+		takes         int
+		wantAllowed   int
+		wantRemaining int
+	}{
+		{name: "burst içinde", rate: 1, burst: 5, takes: 3, wantAllowed: 3, wantRemaining: 2},
+		{name: "burst tükenir", rate: 1, burst: 3, takes: 5, wantAllowed: 3, wantRemaining: 0},
+		{name: "tek token", rate: 0.5, burst: 1, takes: 2, wantAllowed: 1, wantRemaining: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryRateLimitStore()
+			allowed := 0
+			var last RateLimitResult
+			for i := 0; i < tt.takes; i++ {
+				result, err := store.Take(context.Background(), "client", tt.rate, tt.burst)
+				if err != nil {
+					t.Fatalf("Take: %v", err)
+				}
+				if result.Allowed {
+					allowed++
+				}
+				last = result
+			}
+
+			if allowed != tt.wantAllowed {
+				t.Errorf("izin verilen = %d, beklenen %d", allowed, tt.wantAllowed)
+			}
+			if last.Limit != tt.burst || last.Remaining != tt.wantRemaining {
+				t.Errorf("limit = %d, remaining = %d; beklenen %d, %d", last.Limit, last.Remaining, tt.burst, tt.wantRemaining)
+			}
+			maxReset := time.Duration(float64(tt.burst) / tt.rate * float64(time.Second))
+			if last.Reset <= 0 || last.Reset > maxReset {
+				t.Errorf("reset = %v, (0, %v] aralığında olmalı", last.Reset, maxReset)
+			}
+			if !last.Allowed {
+				maxRetry := time.Duration(float64(time.Second) / tt.rate)
+				if last.RetryAfter <= 0 || last.RetryAfter > maxRetry {
+					t.Errorf("retry after = %v, (0, %v] aralığında olmalı", last.RetryAfter, maxRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryRateLimitStoreRefillAndKeys(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	take := func(key string) bool {
+		result, err := store.Take(ctx, key, 100, 1)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		return result.Allowed
+	}
+
+	if !take("a") || take("a") {
+		t.Fatal("tek token'lık bucket iki isteğe izin verdi veya ilkini reddetti")
+	}
+	if !take("b") {
+		t.Fatal("farklı anahtar diğerinin bucket'ından etkilendi")
+	}
+
+	// 100/s hızında 20ms sonra en az bir token dolar
+	time.Sleep(20 * time.Millisecond)
+	if !take("a") {
+		t.Error("bucket zamanla dolmadı")
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		bucket    tokenBucket
+		wantKept  bool
+		lastSweep time.Time
+	}{
+		{
+			name:      "dolmuş ve boşta bucket silinir",
+			bucket:    tokenBucket{last: now.Add(-2 * rateLimitSweepInterval), full: now.Add(-time.Second)},
+			lastSweep: now.Add(-2 * rateLimitSweepInterval),
+			wantKept:  false,
+		},
+		{
+			name:      "henüz dolmamış bucket kalır",
+			bucket:    tokenBucket{last: now.Add(-2 * rateLimitSweepInterval), full: now.Add(time.Hour)},
+			lastSweep: now.Add(-2 * rateLimitSweepInterval),
+			wantKept:  true,
+		},
+		{
+			name:      "yakın zamanda kullanılan bucket kalır",
+			bucket:    tokenBucket{last: now.Add(-time.Second), full: now.Add(-time.Millisecond)},
+			lastSweep: now.Add(-2 * rateLimitSweepInterval),
+			wantKept:  true,
+		},
+		{
+			name:      "sweep aralığı dolmadan temizlik yapılmaz",
+			bucket:    tokenBucket{last: now.Add(-2 * rateLimitSweepInterval), full: now.Add(-time.Second)},
+			lastSweep: now.Add(-time.Second),
+			wantKept:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := tt.bucket
+			store := &MemoryRateLimitStore{
+				buckets:   map[string]*tokenBucket{"client": &bucket},
+				lastSweep: tt.lastSweep,
+			}
+			store.sweep(now)
+			if _, kept := store.buckets["client"]; kept != tt.wantKept {
+				t.Errorf("bucket kaldı = %v, beklenen %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"container/list"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse cache'te tutulan upstream yanıtı
+type CachedResponse struct {
+	Path      string
+	Status    int
+	Header    http.Header
+	Body      []byte
+	StoredAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Fresh yanıtın hala taze olup olmadığını kontrol eder
+func (r *CachedResponse) Fresh(now time.Time) bool {
+	return now.Before(r.ExpiresAt)
+}
+
+// size yanıtın cache'te kapladığı yaklaşık boyut
+func (r *CachedResponse) size() int64 {
+	size := int64(len(r.Body) + len(r.Path))
+	for key, values := range r.Header {
+		size += int64(len(key))
+		for _, value := range values {
+			size += int64(len(value))
+		}
+	}
+	return size
+}
+
+// CacheStats cache istatistikleri
+type CacheStats struct {
+	Entries  int   `json:"entries"`
+	Bytes    int64 `json:"bytes"`
+	MaxBytes int64 `json:"max_bytes"`
+	Hits     int64 `json:"hits"`
+	Misses   int64 `json:"misses"`
+}
+
+// ResponseCache GET yanıtları için cache interface'i
+type ResponseCache interface {
+	Get(r *http.Request) (*CachedResponse, bool)
+	Set(r *http.Request, vary []string, response *CachedResponse)
+	Purge(pathPrefix string) int
+	Stats() CacheStats
+}
+
+// cacheEntry LRU listesindeki eleman
+type cacheEntry struct {
+	key      string
+	base     string
+	response *CachedResponse
+}
+
+// varyIndex temel anahtarın Vary header listesi ve bu anahtarla cache'teki kayıt sayısı
+type varyIndex struct {
+	names   []string
+	entries int
+}
+
+// LRUResponseCache boyut sınırlı LRU ResponseCache implementasyonu
+type LRUResponseCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	order    *list.List
+	entries  map[string]*list.Element
+	vary     map[string]*varyIndex
+	hits     int64
+	misses   int64
+}
+
+// NewLRUResponseCache yeni LRU response cache oluşturur
+func NewLRUResponseCache(maxBytes int64) ResponseCache {
+	return &LRUResponseCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		vary:     make(map[string]*varyIndex),
+	}
+}
+
+// Get isteğe uyan taze yanıtı döner
+func (c *LRUResponseCache) Get(r *http.Request) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := baseCacheKey(r)
+	var vary []string
+	if index, ok := c.vary[base]; ok {
+		vary = index.names
+	}
+	element, ok := c.entries[variantCacheKey(base, vary, r)]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if !entry.response.Fresh(time.Now()) {
+		c.removeElement(element)
+		c.misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits++
+	return entry.response, true
+}
+
+// Set yanıtı Vary header'larına göre oluşturulan anahtarla cache'e ekler
+func (c *LRUResponseCache) Set(r *http.Request, vary []string, response *CachedResponse) {
+	size := response.size()
+	if size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := baseCacheKey(r)
+	key := variantCacheKey(base, vary, r)
+
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+
+	index, ok := c.vary[base]
+	if !ok {
+		index = &varyIndex{}
+		c.vary[base] = index
+	}
+	index.names = vary
+	index.entries++
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, base: base, response: response})
+	c.bytes += size
+
+	// Limit aşılırsa en eski kullanılanları çıkar
+	for c.bytes > c.maxBytes {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Purge path'i verilen prefix ile başlayan tüm yanıtları siler (boş prefix tüm cache'i temizler)
+func (c *LRUResponseCache) Purge(pathPrefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	purged := 0
+	for _, element := range c.entries {
+		if strings.HasPrefix(element.Value.(*cacheEntry).response.Path, pathPrefix) {
+			c.removeElement(element)
+			purged++
+		}
+	}
+	return purged
+}
+
+// Stats cache istatistiklerini döner
+func (c *LRUResponseCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Entries:  len(c.entries),
+		Bytes:    c.bytes,
+		MaxBytes: c.maxBytes,
+		Hits:     c.hits,
+		Misses:   c.misses,
+	}
+}
+
+// removeElement elemanı LRU listesinden ve index'ten siler, temel anahtarın son kaydıysa Vary listesini de siler
+func (c *LRUResponseCache) removeElement(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.response.size()
+
+	if index, ok := c.vary[entry.base]; ok {
+		if index.entries--; index.entries <= 0 {
+			delete(c.vary, entry.base)
+		}
+	}
+}
+
+// baseCacheKey method, path ve query'den temel cache anahtarını oluşturur
+func baseCacheKey(r *http.Request) string {
+	return r.Method + " " + r.URL.Path + "?" + r.URL.Query().Encode()
+}
+
+// variantCacheKey temel anahtara Vary header değerlerini ekler
+func variantCacheKey(base string, vary []string, r *http.Request) string {
+	if len(vary) == 0 {
+		return base
+	}
+
+	names := append([]string(nil), vary...)
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(base)
+	for _, name := range names {
+		b.WriteString("|")
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+	return b.String()
+}
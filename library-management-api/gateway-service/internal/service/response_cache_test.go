@@ -0,0 +1,156 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCachedResponse(path, body string, ttl time.Duration) *CachedResponse {
+	now := time.Now()
+	return &CachedResponse{
+		Path:      path,
+		Status:    http.StatusOK,
+		Header:    http.Header{},
+		Body:      []byte(body),
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+func TestCacheKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		headersA map[string]string
+		headersB map[string]string
+		vary     []string
+		wantSame bool
+	}{
+		{name: "query sırası önemsiz", a: "/api/books?page=1&limit=10", b: "/api/books?limit=10&page=1", wantSame: true},
+		{name: "farklı query", a: "/api/books?page=1", b: "/api/books?page=2", wantSame: false},
+		{name: "vary yoksa header önemsiz", a: "/api/books", b: "/api/books", headersA: map[string]string{"Accept-Language": "tr"}, wantSame: true},
+		{
+			name: "vary header'ı farklı", a: "/api/books", b: "/api/books", vary: []string{"Accept-Language"},
+			headersA: map[string]string{"Accept-Language": "tr"}, headersB: map[string]string{"Accept-Language": "en"}, wantSame: false,
+		},
+		{
+			name: "vary dışındaki header önemsiz", a: "/api/books", b: "/api/books", vary: []string{"Accept-Language"},
+			headersA: map[string]string{"Accept-Language": "tr", "X-Request-ID": "a"}, headersB: map[string]string{"Accept-Language": "tr", "X-Request-ID": "b"}, wantSame: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyFor := func(target string, headers map[string]string) string {
+				r := httptest.NewRequest("GET", target, nil)
+				for name, value := range headers {
+					r.Header.Set(name, value)
+				}
+				return variantCacheKey(baseCacheKey(r), tt.vary, r)
+			}
+			a, b := keyFor(tt.a, tt.headersA), keyFor(tt.b, tt.headersB)
+			if (a == b) != tt.wantSame {
+				t.Errorf("anahtarlar %q ve %q, aynı olması bekleniyor = %v", a, b, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestLRUResponseCacheVary(t *testing.T) {
+	cache := NewLRUResponseCache(1 << 20)
+
+	request := func(language string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/books", nil)
+		r.Header.Set("Accept-Language", language)
+		return r
+	}
+	cache.Set(request("tr"), []string{"Accept-Language"}, newCachedResponse("/api/books", "kitaplar", time.Minute))
+
+	if response, ok := cache.Get(request("tr")); !ok || string(response.Body) != "kitaplar" {
+		t.Fatalf("aynı varyant cache'ten dönmedi: %v", ok)
+	}
+	if _, ok := cache.Get(request("en")); ok {
+		t.Fatal("farklı Accept-Language için başka dilin yanıtı döndü")
+	}
+
+	cache.Set(request("en"), []string{"Accept-Language"}, newCachedResponse("/api/books", "books", time.Minute))
+	if response, ok := cache.Get(request("en")); !ok || string(response.Body) != "books" {
+		t.Fatal("ikinci varyant cache'ten dönmedi")
+	}
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("istatistikler = %+v", stats)
+	}
+}
+
+func TestLRUResponseCacheVaryCleanup(t *testing.T) {
+	entry := newCachedResponse("/api/books/1", "0123456789", time.Minute)
+	cache := NewLRUResponseCache(2 * entry.size()).(*LRUResponseCache)
+
+	set := func(path, language string) {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Accept-Language", language)
+		cache.Set(r, []string{"Accept-Language"}, newCachedResponse(path, "0123456789", time.Minute))
+	}
+
+	set("/api/books/1", "tr")
+	set("/api/books/1", "en")
+	set("/api/books/2", "tr")
+	if len(cache.vary) != 2 {
+		t.Fatalf("vary index sayısı = %d, beklenen 2", len(cache.vary))
+	}
+
+	// /api/books/1'in iki varyantından biri çıkarıldı, diğeri hala Vary listesini kullanıyor
+	if _, ok := cache.vary[baseCacheKey(httptest.NewRequest("GET", "/api/books/1", nil))]; !ok {
+		t.Fatal("kaydı kalan temel anahtarın Vary listesi silindi")
+	}
+
+	set("/api/books/3", "tr")
+	if len(cache.vary) != 2 {
+		t.Errorf("LRU çıkarması sonrası vary index sayısı = %d, beklenen 2", len(cache.vary))
+	}
+
+	cache.Purge("")
+	if len(cache.vary) != 0 {
+		t.Errorf("purge sonrası vary index sayısı = %d, beklenen 0", len(cache.vary))
+	}
+}
+
+func TestLRUResponseCacheEvictionAndPurge(t *testing.T) {
+	entry := newCachedResponse("/api/books/1", "0123456789", time.Minute)
+	cache := NewLRUResponseCache(2 * entry.size())
+
+	set := func(path string, ttl time.Duration) {
+		cache.Set(httptest.NewRequest("GET", path, nil), nil, newCachedResponse(path, "0123456789", ttl))
+	}
+	get := func(path string) bool {
+		_, ok := cache.Get(httptest.NewRequest("GET", path, nil))
+		return ok
+	}
+
+	set("/api/books/1", time.Minute)
+	set("/api/books/2", time.Minute)
+	get("/api/books/1")
+	set("/api/books/3", time.Minute)
+	if get("/api/books/2") || !get("/api/books/1") || !get("/api/books/3") {
+		t.Fatal("en uzun süredir kullanılmayan yanıt çıkarılmadı")
+	}
+
+	set("/api/books/4", -time.Second)
+	if get("/api/books/4") {
+		t.Fatal("süresi dolmuş yanıt döndü")
+	}
+
+	cache.Set(httptest.NewRequest("GET", "/api/authors", nil), nil, newCachedResponse("/api/authors", string(make([]byte, 1<<10)), time.Minute))
+	if get("/api/authors") {
+		t.Fatal("cache'ten büyük yanıt eklendi")
+	}
+
+	// Süresi dolmuş yanıt eklenirken /api/books/1 çıkarıldı, sadece /api/books/3 kaldı
+	if purged := cache.Purge("/api/books"); purged != 1 {
+		t.Errorf("silinen = %d, beklenen 1", purged)
+	}
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("purge sonrası istatistikler = %+v", stats)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+)
+
+// idempotencyHeaders isteğin güvenle tekrarlanabileceğini belirten header'lar
+var idempotencyHeaders = []string{"Idempotency-Key", "X-Idempotency-Key"}
+
+// RetryBudget upstream bazında retry oranını sınırlayan token bucket.
+// Her yeni istek ratio kadar token ekler, her retry bir token harcar; birikebilecek token sayısı
+// burst ile sınırlıdır. Böylece upstream yavaşladığında retry fırtınası önlenir.
+type RetryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+	max    float64
+}
+
+// newRetryBudget yeni retry bütçesi oluşturur
+func newRetryBudget(config configs.RetryConfig) *RetryBudget {
+	return &RetryBudget{
+		tokens: float64(config.BudgetBurst),
+		ratio:  config.BudgetRatio,
+		max:    float64(config.BudgetBurst),
+	}
+}
+
+// deposit yeni istek için bütçeye token ekler
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+// withdraw retry için token harcar; bütçe yoksa false döner
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RetryPolicy gateway retry kuralları ve upstream bütçeleri
+type RetryPolicy struct {
+	config configs.RetryConfig
+
+	mu      sync.Mutex
+	budgets map[string]*RetryBudget
+}
+
+// NewRetryPolicy yeni retry politikası oluşturur
+func NewRetryPolicy(config configs.RetryConfig) *RetryPolicy {
+	return &RetryPolicy{
+		config:  config,
+		budgets: make(map[string]*RetryBudget),
+	}
+}
+
+// IsRetryable isteğin metoduna veya idempotency anahtarına göre tekrarlanabilir olup olmadığını kontrol eder
+func (p *RetryPolicy) IsRetryable(r *http.Request) bool {
+	if !p.config.Enabled || p.config.MaxRetries < 1 {
+		return false
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	for _, header := range idempotencyHeaders {
+		if r.Header.Get(header) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryableStatus yanıt kodunun retry gerektirip gerektirmediğini kontrol eder
+func (p *RetryPolicy) RetryableStatus(statusCode int) bool {
+	for _, code := range p.config.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryableError upstream hatasının retry gerektirip gerektirmediğini kontrol eder.
+// İstemci iptali ve toplam timeout aşımı tekrar denenmez.
+func (p *RetryPolicy) RetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, ErrNoUpstreamInstance) && !errors.Is(err, errProxyRequest)
+}
+
+// Deposit upstream'e gelen yeni istek için bütçeye pay ekler
+func (p *RetryPolicy) Deposit(upstream string) {
+	p.budget(upstream).deposit()
+}
+
+// Allow retry sayısı ve upstream bütçesi uygunsa retry'a izin verir
+func (p *RetryPolicy) Allow(upstream string, retries int) bool {
+	if retries >= p.config.MaxRetries {
+		return false
+	}
+	return p.budget(upstream).withdraw()
+}
+
+// Backoff exponential backoff + full jitter ile bekleme süresini hesaplar
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	delay := p.config.BaseDelay << retry
+	if delay <= 0 || delay > p.config.MaxDelay {
+		delay = p.config.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// Wait backoff süresi kadar bekler; context iptal edilirse false döner
+func (p *RetryPolicy) Wait(ctx context.Context, retry int) bool {
+	timer := time.NewTimer(p.Backoff(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// BufferBody tekrar gönderilebilmesi için istek body'sini belleğe alır.
+// Body limitten büyükse okunan kısım ile kalan akış birleştirilip döner ve retry devre dışı kalır.
+func (p *RetryPolicy) BufferBody(r *http.Request) ([]byte, io.Reader, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody || (r.ContentLength == 0 && len(r.TransferEncoding) == 0) {
+		return nil, nil, true, nil
+	}
+	if r.ContentLength > p.config.MaxBodyBytes {
+		return nil, r.Body, false, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, p.config.MaxBodyBytes+1))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if int64(len(data)) > p.config.MaxBodyBytes {
+		return nil, io.MultiReader(bytes.NewReader(data), r.Body), false, nil
+	}
+	return data, nil, true, nil
+}
+
+// budget upstream için retry bütçesini döner, yoksa oluşturur
+func (p *RetryPolicy) budget(upstream string) *RetryBudget {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	budget, ok := p.budgets[upstream]
+	if !ok {
+		budget = newRetryBudget(p.config)
+		p.budgets[upstream] = budget
+	}
+	return budget
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway-service/configs"
+)
+
+func newTestRetryPolicy() *RetryPolicy {
+	return NewRetryPolicy(configs.RetryConfig{
+		Enabled:      true,
+		MaxRetries:   2,
+		BaseDelay:    100 * time.Millisecond,
+		MaxDelay:     time.Second,
+		StatusCodes:  []int{502, 503, 504},
+		BudgetRatio:  0.5,
+		BudgetBurst:  2,
+		MaxBodyBytes: 8,
+	})
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		header string
+		want   bool
+	}{
+		{name: "GET", method: "GET", want: true},
+		{name: "HEAD", method: "HEAD", want: true},
+		{name: "POST", method: "POST", want: false},
+		{name: "idempotency anahtarlı POST", method: "POST", header: "Idempotency-Key", want: true},
+		{name: "X-Idempotency-Key'li PATCH", method: "PATCH", header: "X-Idempotency-Key", want: true},
+	}
+
+	policy := newTestRetryPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, "/", nil)
+			if tt.header != "" {
+				request.Header.Set(tt.header, "abc")
+			}
+			if got := policy.IsRetryable(request); got != tt.want {
+				t.Errorf("IsRetryable = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+
+	disabled := NewRetryPolicy(configs.RetryConfig{Enabled: false, MaxRetries: 2})
+	if disabled.IsRetryable(httptest.NewRequest("GET", "/", nil)) {
+		t.Error("kapalı retry politikası GET'i tekrarlanabilir saydı")
+	}
+}
+
+func TestRetryPolicyRetryableErrorAndStatus(t *testing.T) {
+	policy := newTestRetryPolicy()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "bağlantı hatası", ctx: context.Background(), err: errors.New("connection refused"), want: true},
+		{name: "açık circuit", ctx: context.Background(), err: ErrCircuitOpen, want: false},
+		{name: "instance yok", ctx: context.Background(), err: ErrNoUpstreamInstance, want: false},
+		{name: "istemci iptali", ctx: canceled, err: errors.New("connection reset"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.RetryableError(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("RetryableError = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+
+	for status, want := range map[int]bool{502: true, 503: true, 504: true, 500: false, 429: false} {
+		if got := policy.RetryableStatus(status); got != want {
+			t.Errorf("RetryableStatus(%d) = %v, beklenen %v", status, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyBudget(t *testing.T) {
+	policy := newTestRetryPolicy()
+
+	// Burst kadar retry hakkı ile başlanır, retry sayısı MaxRetries'ı geçemez
+	if policy.Allow(testUpstream, 2) {
+		t.Fatal("MaxRetries aşıldığı halde retry'a izin verildi")
+	}
+	if !policy.Allow(testUpstream, 0) || !policy.Allow(testUpstream, 1) {
+		t.Fatal("burst içindeki retry'lar reddedildi")
+	}
+	if policy.Allow(testUpstream, 0) {
+		t.Fatal("tükenen bütçe retry'a izin verdi")
+	}
+
+	// Her istek ratio kadar pay ekler: 0.5 oranında iki istek bir retry hakkı kazandırır
+	policy.Deposit(testUpstream)
+	if policy.Allow(testUpstream, 0) {
+		t.Fatal("yarım token ile retry'a izin verildi")
+	}
+	policy.Deposit(testUpstream)
+	if !policy.Allow(testUpstream, 0) {
+		t.Fatal("biriken pay retry'a izin vermedi")
+	}
+
+	// Bütçe burst'ten fazla birikmez ve upstream'ler birbirinden bağımsızdır
+	for i := 0; i < 10; i++ {
+		policy.Deposit(testUpstream)
+	}
+	for i := 0; i < 2; i++ {
+		if !policy.Allow(testUpstream, 0) {
+			t.Fatalf("%d. retry reddedildi", i+1)
+		}
+	}
+	if policy.Allow(testUpstream, 0) {
+		t.Error("bütçe burst sınırını aştı")
+	}
+	if !policy.Allow("author-service", 0) {
+		t.Error("başka upstream'in bütçesi etkilendi")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := newTestRetryPolicy()
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 0, max: 100 * time.Millisecond},
+		{retry: 1, max: 200 * time.Millisecond},
+		{retry: 3, max: 800 * time.Millisecond},
+		{retry: 4, max: time.Second},
+		{retry: 62, max: time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if delay := policy.Backoff(tt.retry); delay < 0 || delay > tt.max {
+				t.Fatalf("Backoff(%d) = %v, [0, %v] aralığında olmalı", tt.retry, delay, tt.max)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if policy.Wait(ctx, 4) {
+		t.Error("iptal edilen context'te Wait true döndü")
+	}
+}
+
+func TestRetryPolicyBufferBody(t *testing.T) {
+	policy := newTestRetryPolicy()
+
+	tests := []struct {
+		name          string
+		body          string
+		chunked       bool
+		wantBuffered  bool
+		wantRetryable bool
+	}{
+		{name: "boş body", body: "", wantRetryable: true},
+		{name: "limit içinde", body: "12345678", wantBuffered: true, wantRetryable: true},
+		{name: "limit aşılır", body: "123456789", wantRetryable: false},
+		{name: "uzunluğu bilinmeyen büyük body", body: "123456789", chunked: true, wantRetryable: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			if tt.chunked {
+				request.ContentLength = -1
+				request.TransferEncoding = []string{"chunked"}
+			}
+
+			data, rest, retryable, err := policy.BufferBody(request)
+			if err != nil {
+				t.Fatalf("BufferBody: %v", err)
+			}
+			if retryable != tt.wantRetryable {
+				t.Errorf("retryable = %v, beklenen %v", retryable, tt.wantRetryable)
+			}
+			if tt.wantBuffered && string(data) != tt.body {
+				t.Errorf("belleğe alınan body = %q, beklenen %q", data, tt.body)
+			}
+			// Retry'a uygun olmayan body'ler eksiksiz akış olarak iletilir
+			if !retryable {
+				forwarded, _ := io.ReadAll(rest)
+				if !bytes.Equal(forwarded, []byte(tt.body)) {
+					t.Errorf("iletilen body = %q, beklenen %q", forwarded, tt.body)
+				}
+			}
+		})
+	}
+}
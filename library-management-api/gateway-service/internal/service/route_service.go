@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+)
+
+// RouteService gateway route tablosu interface'i
+type RouteService interface {
+	Match(path string) (*configs.RouteConfig, bool)
+	Routes() []configs.RouteConfig
+	RateLimit(group string) (*configs.RateLimitConfig, bool)
+	Reload() error
+	Watch(ctx context.Context)
+}
+
+// RouteServiceImpl RouteService implementasyonu
+type RouteServiceImpl struct {
+	mu         sync.RWMutex
+	routes     []configs.RouteConfig
+	versioning *configs.VersioningConfig
+	rateLimits map[string]configs.RateLimitConfig
+	file       string
+	interval   time.Duration
+	services   configs.ServicesConfig
+	modTime    time.Time
+}
+
+// NewRouteService başlangıçta doğrulanmış route'larla yeni route service oluşturur
+func NewRouteService(cfg *configs.Config) RouteService {
+	s := &RouteServiceImpl{
+		routes:     cfg.Routing.Routes,
+		versioning: cfg.Routing.Versioning,
+		rateLimits: cfg.Routing.RateLimits,
+		file:       cfg.Routing.File,
+		interval:   cfg.Routing.ReloadInterval,
+		services:   cfg.Services,
+	}
+	if info, err := os.Stat(s.file); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return s
+}
+
+// Match path'e uyan en uzun prefix'li route'u döner; /api/v2/... gibi versiyonlu path'ler versiyonuna göre eşlenir
+func (s *RouteServiceImpl) Match(path string) (*configs.RouteConfig, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return configs.MatchRoute(s.routes, s.versioning, path)
+}
+
+// Routes mevcut route tablosunun kopyasını döner
+func (s *RouteServiceImpl) Routes() []configs.RouteConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	routes := make([]configs.RouteConfig, len(s.routes))
+	copy(routes, s.routes)
+	return routes
+}
+
+// RateLimit route dosyasındaki rate limit grubunu döner; route tablosu dışındaki endpoint'ler için kullanılır
+func (s *RouteServiceImpl) RateLimit(group string) (*configs.RateLimitConfig, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit, ok := s.rateLimits[group]
+	if !ok {
+		return nil, false
+	}
+	limit.Group = group
+	return &limit, true
+}
+
+// Reload route dosyasını yeniden okur; dosya geçersizse mevcut tablo korunur
+func (s *RouteServiceImpl) Reload() error {
+	if s.file == "" {
+		return nil
+	}
+
+	file, err := configs.LoadRoutes(s.file, s.services)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.routes = file.Routes
+	s.versioning = file.Versioning
+	s.rateLimits = file.RateLimits
+	s.mu.Unlock()
+
+	logging.Infof("🔁 Route tablosu yeniden yüklendi (%d route): %s", len(file.Routes), s.file)
+	return nil
+}
+
+// Watch route dosyasını periyodik olarak kontrol eder ve değiştiğinde yeniden yükler
+func (s *RouteServiceImpl) Watch(ctx context.Context) {
+	if s.file == "" || s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(s.file)
+			if err != nil {
+				logging.Warnf("⚠️ Route dosyası okunamadı: %v", err)
+				continue
+			}
+			if !info.ModTime().After(s.modTime) {
+				continue
+			}
+			s.modTime = info.ModTime()
+
+			if err := s.Reload(); err != nil {
+				logging.Errorf("❌ Route tablosu yeniden yüklenemedi, önceki tablo kullanılmaya devam ediyor: %v", err)
+			}
+		}
+	}
+}
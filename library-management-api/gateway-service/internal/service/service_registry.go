@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+)
+
+// ErrUnknownService gateway'in tanımadığı bir servis adıyla kayıt yapılmak istendiğinde döner
+var ErrUnknownService = errors.New("tanımsız servis")
+
+// ErrInstanceNotRegistered heartbeat veya kayıt silme isteğindeki instance registry'de bulunmadığında döner
+var ErrInstanceNotRegistered = errors.New("instance kayıtlı değil")
+
+// ErrInvalidInstanceURL kaydedilmek istenen instance adresi geçerli bir http(s) URL'i olmadığında döner
+var ErrInvalidInstanceURL = errors.New("geçersiz instance URL'i")
+
+// RegisteredInstance service registry'ye kaydolmuş instance
+type RegisteredInstance struct {
+	ID            string    `json:"id"`
+	Service       string    `json:"service"`
+	URL           string    `json:"url"`
+	RegisteredAt  time.Time `json:"registered_at"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// ServiceRegistry servislerin kendilerini kaydettiği ve heartbeat gönderdiği registry interface'i
+type ServiceRegistry interface {
+	Register(service, url string) (RegisteredInstance, error)
+	Heartbeat(id string) (RegisteredInstance, error)
+	Deregister(id string) (RegisteredInstance, error)
+	Instances(service string) []RegisteredInstance
+	Services() map[string][]RegisteredInstance
+	TTL() time.Duration
+	Start(ctx context.Context)
+}
+
+// MemoryServiceRegistry bellekte tutulan ServiceRegistry implementasyonu.
+// Kayıtlar değiştikçe load balancer havuzu güncellenir: servisin kayıtlı instance'ı varsa sadece onlar,
+// yoksa konfigürasyondaki statik URL'ler kullanılır.
+type MemoryServiceRegistry struct {
+	ttl          time.Duration
+	static       map[string][]string
+	loadBalancer LoadBalancer
+
+	mu        sync.Mutex
+	instances map[string]*RegisteredInstance
+
+	// applyMu kayıt anlık görüntüsünün alınması ile load balancer'a uygulanmasını tek adımda yapar;
+	// eşzamanlı kayıt/silme/süre dolumunda eski bir görüntü yenisinin üzerine yazılamaz
+	applyMu sync.Mutex
+}
+
+// NewMemoryServiceRegistry yeni service registry oluşturur; sadece konfigürasyonda tanımlı servisler kaydolabilir
+func NewMemoryServiceRegistry(config configs.RegistryConfig, services configs.ServicesConfig, loadBalancer LoadBalancer) ServiceRegistry {
+	return &MemoryServiceRegistry{
+		ttl:          config.TTL,
+		static:       services.ByName(),
+		loadBalancer: loadBalancer,
+		instances:    make(map[string]*RegisteredInstance),
+	}
+}
+
+// TTL heartbeat gelmeyen kaydın silinmesine kadar geçen süreyi döner
+func (r *MemoryServiceRegistry) TTL() time.Duration {
+	return r.ttl
+}
+
+// Register instance'ı kaydeder; aynı servis ve URL ile tekrar kayıt heartbeat gibi davranır
+func (r *MemoryServiceRegistry) Register(service, rawURL string) (RegisteredInstance, error) {
+	if _, ok := r.static[service]; !ok {
+		return RegisteredInstance{}, fmt.Errorf("%s: %w", service, ErrUnknownService)
+	}
+	instanceURL, err := normalizeInstanceURL(rawURL)
+	if err != nil {
+		return RegisteredInstance{}, err
+	}
+
+	now := time.Now()
+	id := instanceID(service, instanceURL)
+
+	r.mu.Lock()
+	instance, exists := r.instances[id]
+	if !exists {
+		instance = &RegisteredInstance{ID: id, Service: service, URL: instanceURL, RegisteredAt: now}
+		r.instances[id] = instance
+	}
+	instance.LastHeartbeat = now
+	instance.ExpiresAt = now.Add(r.ttl)
+	registered := *instance
+	r.mu.Unlock()
+
+	if !exists {
+		logging.Infof("📇 [%s] Instance kaydoldu: %s", service, instanceURL)
+		r.apply(service)
+	}
+	return registered, nil
+}
+
+// Heartbeat kaydın süresini uzatır; kayıt silinmişse ErrInstanceNotRegistered döner ve servis yeniden kaydolmalıdır
+func (r *MemoryServiceRegistry) Heartbeat(id string) (RegisteredInstance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instance, ok := r.instances[id]
+	if !ok {
+		return RegisteredInstance{}, ErrInstanceNotRegistered
+	}
+	now := time.Now()
+	instance.LastHeartbeat = now
+	instance.ExpiresAt = now.Add(r.ttl)
+	return *instance, nil
+}
+
+// Deregister kaydı siler; servis kapanırken çağrılır
+func (r *MemoryServiceRegistry) Deregister(id string) (RegisteredInstance, error) {
+	r.mu.Lock()
+	instance, ok := r.instances[id]
+	delete(r.instances, id)
+	r.mu.Unlock()
+
+	if !ok {
+		return RegisteredInstance{}, ErrInstanceNotRegistered
+	}
+	logging.Infof("📇 [%s] Instance kaydı silindi: %s", instance.Service, instance.URL)
+	r.apply(instance.Service)
+	return *instance, nil
+}
+
+// Instances servisin süresi dolmamış kayıtlarını URL sırasıyla döner
+func (r *MemoryServiceRegistry) Instances(service string) []RegisteredInstance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	result := []RegisteredInstance{}
+	for _, instance := range r.instances {
+		if instance.Service == service && now.Before(instance.ExpiresAt) {
+			result = append(result, *instance)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].URL < result[j].URL })
+	return result
+}
+
+// Services tüm servislerin süresi dolmamış kayıtlarını döner
+func (r *MemoryServiceRegistry) Services() map[string][]RegisteredInstance {
+	result := make(map[string][]RegisteredInstance, len(r.static))
+	for service := range r.static {
+		result[service] = r.Instances(service)
+	}
+	return result
+}
+
+// Start context iptal edilene kadar süresi dolan kayıtları periyodik olarak siler
+func (r *MemoryServiceRegistry) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.expire(time.Now())
+		}
+	}
+}
+
+// expire heartbeat göndermeyi bırakan instance'ları siler ve etkilenen servislerin havuzlarını günceller
+func (r *MemoryServiceRegistry) expire(now time.Time) {
+	var expired []RegisteredInstance
+	r.mu.Lock()
+	for id, instance := range r.instances {
+		if !now.Before(instance.ExpiresAt) {
+			expired = append(expired, *instance)
+			delete(r.instances, id)
+		}
+	}
+	r.mu.Unlock()
+
+	changed := make(map[string]bool)
+	for _, instance := range expired {
+		logging.Warnf("⌛ [%s] Heartbeat gelmediği için instance kaydı silindi: %s (son heartbeat %s)",
+			instance.Service, instance.URL, instance.LastHeartbeat.Format(time.RFC3339))
+		changed[instance.Service] = true
+	}
+	for service := range changed {
+		r.apply(service)
+	}
+}
+
+// apply servisin load balancer havuzunu kayıtlı instance'larla, kayıt yoksa statik URL'lerle eşitler
+func (r *MemoryServiceRegistry) apply(service string) {
+	r.applyMu.Lock()
+	defer r.applyMu.Unlock()
+
+	var urls []string
+	for _, instance := range r.Instances(service) {
+		urls = append(urls, instance.URL)
+	}
+	source := "registry"
+	if len(urls) == 0 {
+		urls = r.static[service]
+		source = "statik konfigürasyon"
+	}
+
+	added, removed := r.loadBalancer.SyncInstances(service, urls)
+	if len(added) > 0 || len(removed) > 0 {
+		logging.Infof("🔀 [%s] Upstream instance'ları güncellendi (%s): +%v -%v", service, source, added, removed)
+	}
+}
+
+// normalizeInstanceURL instance adresini doğrular ve sondaki '/' karakterini kaldırır
+func normalizeInstanceURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidInstanceURL, raw)
+	}
+	return strings.TrimSuffix(parsed.String(), "/"), nil
+}
+
+// instanceID servis ve URL'den sabit kayıt ID'si üretir; yeniden başlayan instance aynı ID ile kaydolur
+func instanceID(service, instanceURL string) string {
+	sum := sha256.Sum256([]byte(service + "|" + instanceURL))
+	return hex.EncodeToString(sum[:8])
+}
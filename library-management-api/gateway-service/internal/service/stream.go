@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// upgradeProtocol isteğin WebSocket upgrade isteği olup olmadığını kontrol eder; öyleyse Upgrade değerini döner
+func upgradeProtocol(header http.Header) string {
+	if !headerHasToken(header, "Connection", "upgrade") {
+		return ""
+	}
+	protocol := header.Get("Upgrade")
+	if !strings.EqualFold(protocol, "websocket") {
+		return ""
+	}
+	return protocol
+}
+
+// IsUpgradeRequest isteğin WebSocket bağlantısına yükseltme isteği olup olmadığını döner
+func IsUpgradeRequest(r *http.Request) bool {
+	return upgradeProtocol(r.Header) != ""
+}
+
+// headerHasToken virgülle ayrılmış header değerlerinde token'ı (büyük/küçük harf duyarsız) arar
+func headerHasToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isEventStream yanıtın Server-Sent Events akışı olup olmadığını kontrol eder
+func isEventStream(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == "text/event-stream"
+}
+
+// tunnel upstream'in 101 Switching Protocols yanıtını istemciye iletir ve iki bağlantı arasında
+// veriyi her iki yönde kopyalar. Tünel bir taraf kapanana veya idle timeout dolana kadar açık kalır.
+func (s *ProxyServiceImpl) tunnel(c *gin.Context, route *configs.RouteConfig, resp *http.Response, deadline *requestDeadline, idleTimeout time.Duration) {
+	serviceName := route.Upstream
+	ctx := c.Request.Context()
+
+	upstream, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok || upgradeProtocol(c.Request.Header) == "" {
+		requestid.Errorf(ctx, "❌ [%s] Beklenmeyen protokol değişimi yanıtı: %s", serviceName, c.Request.URL.Path)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":       "UPGRADE_FAILED",
+				"message":    fmt.Sprintf("%s servisinden geçersiz upgrade yanıtı", serviceName),
+				"service":    serviceName,
+				"request_id": requestid.Get(c),
+			},
+		})
+		return
+	}
+
+	header := c.Writer.Header()
+	copyResponseHeaders(resp.Header, header, route.ResponseHeaders())
+	header.Set("Connection", "Upgrade")
+	header.Set("Upgrade", resp.Header.Get("Upgrade"))
+	c.Writer.WriteHeader(http.StatusSwitchingProtocols)
+
+	conn, client, err := c.Writer.Hijack()
+	if err != nil {
+		requestid.Errorf(ctx, "❌ [%s] İstemci bağlantısı devralınamadı: %v", serviceName, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+
+	// Bağlantı devralındığı için yanıt satırı ve header'lar doğrudan yazılır
+	fmt.Fprintf(client, "HTTP/1.1 %d %s\r\n", http.StatusSwitchingProtocols, http.StatusText(http.StatusSwitchingProtocols))
+	header.Write(client)
+	client.WriteString("\r\n")
+	if err := client.Flush(); err != nil {
+		requestid.Errorf(ctx, "❌ [%s] Upgrade yanıtı yazılamadı: %v", serviceName, err)
+		return
+	}
+
+	// İstemci veya upstream kapanınca ya da idle timeout dolunca iki bağlantı da kapatılır
+	touch := deadline.idle(idleTimeout)
+	stop := context.AfterFunc(deadline.ctx, func() {
+		conn.Close()
+		upstream.Close()
+	})
+	defer stop()
+
+	requestid.Printf(ctx, "🔌 [%s] WebSocket tüneli açıldı: %s", serviceName, c.Request.URL.Path)
+	start := time.Now()
+
+	sent := make(chan int64, 1)
+	go func() {
+		// İstemcinin upgrade yanıtından önce gönderdiği veri client.Reader'da tamponlanmış olabilir
+		n, _ := io.Copy(upstream, &activityReader{reader: client.Reader, touch: touch})
+		upstream.Close()
+		sent <- n
+	}()
+	received, _ := io.Copy(conn, &activityReader{reader: upstream, touch: touch})
+	conn.Close()
+	upstream.Close()
+
+	reason := "kapandı"
+	if errors.Is(context.Cause(deadline.ctx), errIdleTimeout) {
+		reason = "boşta kaldığı için kapatıldı"
+	}
+	requestid.Printf(ctx, "🔌 [%s] WebSocket tüneli %s (%s, ↑%d ↓%d bytes)",
+		serviceName, reason, time.Since(start).Round(time.Millisecond), <-sent, received)
+}
+
+// activityReader her okumada idle timeout'u yeniler
+type activityReader struct {
+	reader io.Reader
+	touch  func()
+}
+
+// Read alttaki okuyucudan okur ve veri geldiyse idle süresini yeniler
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.touch()
+	}
+	return n, err
+}
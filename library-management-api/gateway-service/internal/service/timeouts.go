@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// errConnectTimeout upstream'e TCP bağlantısı connect timeout içinde kurulamadığında döner
+var errConnectTimeout = errors.New("upstream bağlantı zaman aşımı")
+
+// errResponseHeaderTimeout istek gönderildikten sonra yanıt header'ları zamanında gelmediğinde döner
+var errResponseHeaderTimeout = errors.New("upstream yanıt header zaman aşımı")
+
+// errIdleTimeout streaming yanıt veya WebSocket tüneli idle timeout boyunca veri aktarmadığında döner
+var errIdleTimeout = errors.New("upstream akışı boşta kaldı")
+
+// upstreamTimeouts tek bir upstream denemesine uygulanan bağlantı ve yanıt header timeout'ları
+type upstreamTimeouts struct {
+	connect        time.Duration
+	responseHeader time.Duration
+}
+
+// connectTimeoutKey isteğe özel connect timeout'unu dialer'a taşıyan context anahtarı
+type connectTimeoutKey struct{}
+
+// newTransport connect timeout'unu istek context'inden okuyan HTTP transport'u oluşturur.
+// Transport tüm route'larca paylaşıldığı için timeout dialer'a context üzerinden aktarılır.
+func newTransport(defaultConnectTimeout time.Duration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{KeepAlive: 30 * time.Second}
+
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		timeout := defaultConnectTimeout
+		if value, ok := ctx.Value(connectTimeoutKey{}).(time.Duration); ok && value > 0 {
+			timeout = value
+		}
+
+		dialCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		conn, err := dialer.DialContext(dialCtx, network, address)
+		if err != nil && ctx.Err() == nil && errors.Is(dialCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w (%s): %v", errConnectTimeout, timeout, err)
+		}
+		return conn, err
+	}
+	return transport
+}
+
+// do isteği connect ve yanıt header timeout'larıyla gönderir.
+// Yanıt header timeout'u istek (body dahil) yazıldıktan sonra başlar; yanıt gelince durdurulur,
+// body akışı sadece isteğin toplam timeout'una tabidir.
+func (s *ProxyServiceImpl) do(req *http.Request, timeouts upstreamTimeouts) (*http.Response, error) {
+	ctx, cancel := context.WithCancelCause(context.WithValue(req.Context(), connectTimeoutKey{}, timeouts.connect))
+	timer := &headerTimer{}
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			timer.start(timeouts.responseHeader, func() { cancel(errResponseHeaderTimeout) })
+		},
+	})
+
+	resp, err := s.httpClient.Do(req.WithContext(ctx))
+	timer.stop()
+	if err != nil {
+		if errors.Is(context.Cause(ctx), errResponseHeaderTimeout) {
+			err = fmt.Errorf("%w (%s)", errResponseHeaderTimeout, timeouts.responseHeader)
+		}
+		cancel(nil)
+		return nil, err
+	}
+
+	if errors.Is(context.Cause(ctx), errResponseHeaderTimeout) {
+		// Timer yanıt header'ları gelirken tetiklendi; context iptal edildiğinden body okunamaz
+		resp.Body.Close()
+		cancel(nil)
+		return nil, fmt.Errorf("%w (%s)", errResponseHeaderTimeout, timeouts.responseHeader)
+	}
+
+	if body, ok := resp.Body.(io.ReadWriteCloser); ok {
+		// 101 Switching Protocols yanıtında body upstream bağlantısıdır ve yazılabilir kalmalıdır
+		resp.Body = &cancelOnCloseRW{cancelOnClose: cancelOnClose{ReadCloser: body, cancel: func() { cancel(nil) }}, writer: body}
+		return resp, nil
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: func() { cancel(nil) }}
+	return resp, nil
+}
+
+// isTimeout hatanın upstream timeout'larından (connect, yanıt header, toplam veya idle) biri olup olmadığını döner
+func isTimeout(err error) bool {
+	return errors.Is(err, errConnectTimeout) || errors.Is(err, errResponseHeaderTimeout) ||
+		errors.Is(err, errIdleTimeout) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// requestDeadline proxy isteğinin toplam süresini sınırlar. Streaming yanıtlarda (SSE, WebSocket)
+// toplam süre kaldırılıp yerine her veri aktarımında yenilenen idle timeout kullanılır.
+type requestDeadline struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	timer  *time.Timer
+}
+
+// newRequestDeadline parent context'ten toplam timeout'lu yeni deadline oluşturur
+func newRequestDeadline(parent context.Context, total time.Duration) *requestDeadline {
+	ctx, cancel := context.WithCancelCause(parent)
+	return &requestDeadline{
+		ctx:    ctx,
+		cancel: cancel,
+		timer:  time.AfterFunc(total, func() { cancel(context.DeadlineExceeded) }),
+	}
+}
+
+// idle toplam timeout'u kaldırır ve idle timeout'u başlatır; dönen fonksiyon her aktarımda çağrılarak süreyi yeniler
+func (d *requestDeadline) idle(timeout time.Duration) func() {
+	if !d.timer.Stop() {
+		// Toplam süre zaten doldu
+		return func() {}
+	}
+	d.timer = time.AfterFunc(timeout, func() { d.cancel(errIdleTimeout) })
+	return func() { d.timer.Reset(timeout) }
+}
+
+// wrap context'in timeout ile iptal edilmesinden kaynaklanan hataya timeout nedenini ekler
+func (d *requestDeadline) wrap(err error) error {
+	cause := context.Cause(d.ctx)
+	if err == nil || d.ctx.Err() == nil || !isTimeout(cause) || errors.Is(err, cause) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", cause, err)
+}
+
+// stop timer'ı durdurur ve context'i serbest bırakır
+func (d *requestDeadline) stop() {
+	d.timer.Stop()
+	d.cancel(nil)
+}
+
+// headerTimer yanıt header timeout'unu istek yazıldığında başlatır; httptrace callback'i ayrı goroutine'de çalışabilir
+type headerTimer struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+// start timer'ı başlatır; yanıt zaten geldiyse bir şey yapmaz
+func (t *headerTimer) start(d time.Duration, fire func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.stopped && t.timer == nil {
+		t.timer = time.AfterFunc(d, fire)
+	}
+}
+
+// stop timer'ı durdurur
+func (t *headerTimer) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// cancelOnClose yanıt body'si kapatıldığında isteğin context'ini serbest bırakır
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+// Close body'yi kapatır ve context'i iptal eder
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// cancelOnCloseRW yazılabilir (upgrade edilmiş) yanıt body'si için cancelOnClose
+type cancelOnCloseRW struct {
+	cancelOnClose
+	writer io.Writer
+}
+
+// Write upgrade edilmiş upstream bağlantısına yazar
+func (b *cancelOnCloseRW) Write(p []byte) (int, error) {
+	return b.writer.Write(p)
+}
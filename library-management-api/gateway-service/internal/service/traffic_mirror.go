@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway-service/configs"
+	"gateway-service/internal/logging"
+	"gateway-service/internal/metrics"
+	"gateway-service/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Shadow karşılaştırma sonuçları
+const (
+	MirrorMatch          = "match"
+	MirrorStatusMismatch = "status_mismatch"
+	MirrorBodyMismatch   = "body_mismatch"
+	MirrorShadowError    = "shadow_error"
+	MirrorDropped        = "dropped"
+)
+
+// MirrorRecord gölge yanıtın istemciye dönen yanıtla karşılaştırma kaydı
+type MirrorRecord struct {
+	Time            time.Time `json:"time"`
+	RequestID       string    `json:"request_id"`
+	Route           string    `json:"route"`
+	Path            string    `json:"path"`
+	Upstream        string    `json:"upstream"`
+	ShadowUpstream  string    `json:"shadow_upstream"`
+	Result          string    `json:"result"`
+	Status          int       `json:"status"`
+	ShadowStatus    int       `json:"shadow_status,omitempty"`
+	LatencyMS       float64   `json:"latency_ms"`
+	ShadowLatencyMS float64   `json:"shadow_latency_ms"`
+	ShadowError     string    `json:"shadow_error,omitempty"`
+	BodySkipped     string    `json:"body_skipped,omitempty"`
+	Diffs           []string  `json:"diffs,omitempty"`
+	DiffsTruncated  bool      `json:"diffs_truncated,omitempty"`
+}
+
+// TrafficMirror route'larda tanımlı shadow upstream'lere istek kopyalayan interface
+type TrafficMirror interface {
+	Mirror(c *gin.Context, route *configs.RouteConfig) *MirrorSession
+	Close() error
+}
+
+// TrafficMirrorImpl TrafficMirror implementasyonu. Gölge istekler Fetch ile (circuit breaker ve load balancer
+// üzerinden) gönderilir; bekleyen gölge istek sayısı sınırlıdır ve sınır aşıldığında istek kopyalanmaz.
+type TrafficMirrorImpl struct {
+	proxyService ProxyService
+	metrics      *metrics.MirrorMetrics
+	config       configs.MirrorConfig
+	proxyConfig  configs.ProxyConfig
+	slots        chan struct{}
+
+	mu      sync.Mutex
+	file    *os.File
+	fileErr error
+	closed  bool
+}
+
+// NewTrafficMirror yeni traffic mirror oluşturur. MIRROR_LOG_FILE tanımlıysa dosya ilk karşılaştırma
+// kaydında açılır (mirror tanımlı route yoksa hiç açılmaz); tanımlı değilse kayıtlar log'a yazılır.
+func NewTrafficMirror(proxyService ProxyService, mirrorMetrics *metrics.MirrorMetrics, config configs.MirrorConfig, proxyConfig configs.ProxyConfig) TrafficMirror {
+	return &TrafficMirrorImpl{
+		proxyService: proxyService,
+		metrics:      mirrorMetrics,
+		config:       config,
+		proxyConfig:  proxyConfig,
+		slots:        make(chan struct{}, config.MaxConcurrent),
+	}
+}
+
+// shadowResult gölge isteğin sonucu
+type shadowResult struct {
+	response *UpstreamResponse
+	err      error
+	elapsed  time.Duration
+}
+
+// MirrorSession kopyalanan tek bir isteğin birincil yanıtını yakalar ve gölge yanıtla karşılaştırır
+type MirrorSession struct {
+	mirror   *TrafficMirrorImpl
+	route    *configs.RouteConfig
+	record   MirrorRecord
+	ignore   map[string]bool
+	start    time.Time
+	original gin.ResponseWriter
+	writer   *mirrorWriter
+	shadow   chan shadowResult
+}
+
+// Mirror route'ta mirror tanımlıysa ve istek örneklemeye girdiyse gölge isteği arka planda başlatır ve
+// istemciye giden yanıtı yakalamaya başlar. Sadece GET istekleri kopyalanır; WebSocket ve SSE istekleri hariçtir.
+// Dönen session nil değilse birincil yanıt tamamlandığında Finish çağrılmalıdır.
+func (m *TrafficMirrorImpl) Mirror(c *gin.Context, route *configs.RouteConfig) *MirrorSession {
+	mirror := route.Mirror
+	if mirror == nil || c.Request.Method != http.MethodGet || c.GetHeader("Upgrade") != "" ||
+		strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		return nil
+	}
+	if rand.Float64()*100 >= mirror.Percentage {
+		return nil
+	}
+
+	label := route.RequestPrefix()
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		m.metrics.Result(label, mirror.Upstream, MirrorDropped)
+		requestid.Debugf(c.Request.Context(), "🪞 [%s] Bekleyen shadow istek sınırı dolu, istek kopyalanmadı", mirror.Upstream)
+		return nil
+	}
+
+	targetPath := route.RewritePath(c.Request.URL.Path)
+	if c.Request.URL.RawQuery != "" {
+		targetPath += "?" + c.Request.URL.RawQuery
+	}
+
+	session := &MirrorSession{
+		mirror: m,
+		route:  route,
+		record: MirrorRecord{
+			RequestID:      requestid.Get(c),
+			Route:          label,
+			Path:           targetPath,
+			Upstream:       route.Upstream,
+			ShadowUpstream: mirror.Upstream,
+		},
+		ignore:   make(map[string]bool, len(mirror.IgnoreFields)),
+		start:    time.Now(),
+		original: c.Writer,
+		writer:   &mirrorWriter{ResponseWriter: c.Writer, limit: m.config.MaxBodyBytes},
+		shadow:   make(chan shadowResult, 1),
+	}
+	for _, field := range mirror.IgnoreFields {
+		session.ignore[field] = true
+	}
+	c.Writer = session.writer
+
+	// Gölge istek istemci bağlantısından bağımsızdır; trace ve istek ID'si korunur, süre route timeout'u ile sınırlıdır
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), route.Limits(m.proxyConfig).Timeout)
+	header := c.Request.Header.Clone()
+	go func() {
+		defer cancel()
+		start := time.Now()
+		response, err := m.proxyService.Fetch(ctx, mirror.Upstream, targetPath, header)
+		session.shadow <- shadowResult{response: response, err: err, elapsed: time.Since(start)}
+	}()
+
+	return session
+}
+
+// Finish birincil yanıtı kaydeder ve gölge yanıt geldiğinde karşılaştırmayı arka planda yapar; istemciyi bekletmez
+func (s *MirrorSession) Finish(c *gin.Context) {
+	c.Writer = s.original
+	s.record.Status = s.writer.Status()
+	s.record.LatencyMS = durationMS(time.Since(s.start))
+
+	go func() {
+		defer func() { <-s.mirror.slots }()
+		result := <-s.shadow
+		s.compare(result)
+		s.mirror.write(&s.record)
+	}()
+}
+
+// compare gölge yanıtı birincil yanıtla durum kodu ve body açısından karşılaştırır
+func (s *MirrorSession) compare(result shadowResult) {
+	record := &s.record
+	record.Time = time.Now()
+	record.ShadowLatencyMS = durationMS(result.elapsed)
+	s.mirror.metrics.ObserveShadow(record.Route, record.ShadowUpstream, result.elapsed)
+
+	switch {
+	case result.err != nil:
+		record.Result = MirrorShadowError
+		record.ShadowError = result.err.Error()
+		return
+	case result.response.StatusCode != record.Status:
+		record.Result = MirrorStatusMismatch
+		record.ShadowStatus = result.response.StatusCode
+		return
+	}
+	record.ShadowStatus = result.response.StatusCode
+
+	switch {
+	case s.writer.overflow:
+		record.BodySkipped = fmt.Sprintf("yanıt MIRROR_MAX_BODY_BYTES (%d) sınırını aşıyor", s.writer.limit)
+	case s.writer.encoding != "" && s.writer.encoding != "identity":
+		record.BodySkipped = "upstream yanıtı sıkıştırılmış (" + s.writer.encoding + ")"
+	default:
+		record.Diffs, record.DiffsTruncated = diffBodies(s.writer.body.Bytes(), result.response.Body, s.ignore, s.mirror.config.MaxDiffs)
+	}
+
+	record.Result = MirrorMatch
+	if len(record.Diffs) > 0 {
+		record.Result = MirrorBodyMismatch
+	}
+}
+
+// write karşılaştırma sonucunu metriklere, log'a ve varsa mirror log dosyasına yazar.
+// Mirror log dosyası yoksa body farkları da log satırına eklenir.
+func (m *TrafficMirrorImpl) write(record *MirrorRecord) {
+	m.metrics.Result(record.Route, record.ShadowUpstream, record.Result)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	file := m.logFile()
+
+	ctx := requestid.WithContext(context.Background(), record.RequestID)
+	switch record.Result {
+	case MirrorMatch:
+		requestid.Debugf(ctx, "🪞 [%s] Shadow yanıtı aynı: %s (%.1fms / shadow %.1fms)",
+			record.ShadowUpstream, record.Path, record.LatencyMS, record.ShadowLatencyMS)
+	case MirrorShadowError:
+		requestid.Warnf(ctx, "🪞 [%s] Shadow isteği başarısız: %s: %s",
+			record.ShadowUpstream, record.Path, record.ShadowError)
+	default:
+		diffs := fmt.Sprintf("%d fark", len(record.Diffs))
+		if file == nil && len(record.Diffs) > 0 {
+			diffs += ": " + strings.Join(record.Diffs, "; ")
+		}
+		requestid.Warnf(ctx, "🪞 [%s] Shadow yanıtı farklı (%s): %s -> %d / shadow %d, %s",
+			record.ShadowUpstream, record.Result, record.Path, record.Status, record.ShadowStatus, diffs)
+	}
+
+	if file == nil {
+		return
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		logging.Errorf("❌ Mirror kaydı serialize edilemedi: %v", err)
+		return
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		logging.Errorf("❌ Mirror kaydı yazılamadı: %v", err)
+	}
+}
+
+// logFile mirror log dosyasını ilk çağrıda açar; dosya tanımlı değilse, açılamadıysa veya mirror
+// kapatıldıysa nil döner. m.mu tutulurken çağrılmalıdır.
+func (m *TrafficMirrorImpl) logFile() *os.File {
+	if m.file != nil || m.closed || m.fileErr != nil || m.config.LogFile == "" {
+		return m.file
+	}
+	file, err := os.OpenFile(m.config.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		m.fileErr = err
+		logging.Errorf("❌ Mirror log dosyası açılamadı, kayıtlar sadece log'a yazılacak: %v", err)
+		return nil
+	}
+	m.file = file
+	return file
+}
+
+// Close açıldıysa mirror log dosyasını kapatır
+func (m *TrafficMirrorImpl) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	if m.file == nil {
+		return nil
+	}
+	err := m.file.Close()
+	m.file = nil
+	return err
+}
+
+// durationMS süreyi milisaniye olarak döner
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// mirrorWriter istemciye giden yanıtı yazarken karşılaştırma için limit dahilinde kopyasını tutar
+type mirrorWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	limit    int64
+	overflow bool
+	encoding string
+}
+
+// WriteHeader upstream'in Content-Encoding'ini, dıştaki sıkıştırma middleware'i header'ı değiştirmeden önce saklar
+func (w *mirrorWriter) WriteHeader(code int) {
+	w.encoding = w.Header().Get("Content-Encoding")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write yanıtı istemciye yazar ve karşılaştırma için kopyalar
+func (w *mirrorWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString yanıtı istemciye yazar ve karşılaştırma için kopyalar
+func (w *mirrorWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// capture limit aşılmadıysa veriyi buffer'a ekler
+func (w *mirrorWriter) capture(data []byte) {
+	if w.overflow {
+		return
+	}
+	if int64(w.body.Len()+len(data)) > w.limit {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
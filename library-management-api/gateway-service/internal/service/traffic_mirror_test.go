@@ -0,0 +1,48 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gateway-service/configs"
+	"gateway-service/internal/metrics"
+)
+
+func TestTrafficMirrorOpensLogFileOnFirstRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.jsonl")
+	mirror := NewTrafficMirror(nil, metrics.NewMirrorMetrics(metrics.New("test")), configs.MirrorConfig{LogFile: path, MaxConcurrent: 1}, configs.ProxyConfig{}).(*TrafficMirrorImpl)
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("kayıt yazılmadan mirror log dosyası açıldı: %v", err)
+	}
+
+	mirror.write(&MirrorRecord{RequestID: "abc", Route: "/api/books", ShadowUpstream: "book-service-v2", Result: MirrorMatch})
+	if err := mirror.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("mirror log dosyası okunamadı: %v", err)
+	}
+	var record MirrorRecord
+	if err := json.Unmarshal(data, &record); err != nil || record.RequestID != "abc" {
+		t.Fatalf("kayıt = %+v, hata = %v", record, err)
+	}
+
+	// Kapatıldıktan sonra dosya tekrar açılmaz
+	mirror.write(&MirrorRecord{RequestID: "def", Result: MirrorMatch})
+	if mirror.file != nil {
+		t.Error("Close sonrası mirror log dosyası tekrar açıldı")
+	}
+}
+
+func TestTrafficMirrorWithoutLogFile(t *testing.T) {
+	mirror := NewTrafficMirror(nil, metrics.NewMirrorMetrics(metrics.New("test")), configs.MirrorConfig{MaxConcurrent: 1}, configs.ProxyConfig{}).(*TrafficMirrorImpl)
+	mirror.write(&MirrorRecord{RequestID: "abc", Result: MirrorBodyMismatch, Diffs: []string{"title"}})
+	if mirror.file != nil || mirror.fileErr != nil {
+		t.Errorf("MIRROR_LOG_FILE boşken dosya açılmaya çalışıldı: %v", mirror.fileErr)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gateway-service/configs"
+
+	"github.com/gin-gonic/gin"
+)
+
+var splitServices = configs.ServicesConfig{
+	RecommendationServiceURLs: []string{"http://localhost:3004"},
+	Extra:                     map[string][]string{"recommendation-service-canary": {"http://localhost:3014"}},
+}
+
+// newSplitRoute stable/canary ağırlıklarıyla doğrulanmış split route'u oluşturur
+func newSplitRoute(t *testing.T, stable, canary int) *configs.RouteConfig {
+	t.Helper()
+	routes := []configs.RouteConfig{{
+		Prefix:   "/api/recommendations",
+		Upstream: "recommendation-service",
+		Split: &configs.TrafficSplit{
+			Sticky: configs.StickyUser,
+			Versions: []configs.RouteVersion{
+				{Name: "stable", Upstream: "recommendation-service", Weight: stable},
+				{
+					Name: "canary", Upstream: "recommendation-service-canary", Weight: canary,
+					Header: &configs.SplitMatch{Name: "x-canary", Value: "true"},
+					Cookie: &configs.SplitMatch{Name: "canary"},
+				},
+			},
+		},
+	}}
+	if err := configs.ValidateRoutes(routes, nil, splitServices); err != nil {
+		t.Fatalf("ValidateRoutes: %v", err)
+	}
+	return &routes[0]
+}
+
+func newSplitContext(prepare func(*http.Request)) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/recommendations", nil)
+	if prepare != nil {
+		prepare(c.Request)
+	}
+	return c
+}
+
+func TestTrafficSplitPick(t *testing.T) {
+	split := newSplitRoute(t, 90, 10).Split
+
+	tests := []struct {
+		point int
+		want  string
+	}{
+		{point: 0, want: "stable"},
+		{point: 89, want: "stable"},
+		{point: 90, want: "canary"},
+		{point: 99, want: "canary"},
+		{point: 150, want: "canary"},
+	}
+	if split.TotalWeight() != 100 {
+		t.Fatalf("TotalWeight = %d, beklenen 100", split.TotalWeight())
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.point), func(t *testing.T) {
+			if got := split.Pick(tt.point).Name; got != tt.want {
+				t.Errorf("Pick(%d) = %s, beklenen %s", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrafficSplitterSelect(t *testing.T) {
+	splitter := NewTrafficSplitter(nil)
+	route := newSplitRoute(t, 100, 0)
+
+	tests := []struct {
+		name    string
+		prepare func(*http.Request)
+		want    string
+	}{
+		{name: "koşulsuz istek ağırlığa göre", want: "stable"},
+		{name: "header eşleşir", prepare: func(r *http.Request) { r.Header.Set("X-Canary", "true") }, want: "canary"},
+		{name: "header değeri farklı", prepare: func(r *http.Request) { r.Header.Set("X-Canary", "false") }, want: "stable"},
+		{name: "cookie varlığı yeterli", prepare: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "canary", Value: "1"}) }, want: "canary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitter.Select(newSplitContext(tt.prepare), route, "user-1").Name; got != tt.want {
+				t.Errorf("seçilen = %s, beklenen %s", got, tt.want)
+			}
+		})
+	}
+
+	if version := splitter.Select(newSplitContext(nil), &configs.RouteConfig{Prefix: "/api/books"}, ""); version != nil {
+		t.Errorf("split'siz route için versiyon seçildi: %s", version.Name)
+	}
+}
+
+func TestTrafficSplitterStickyUser(t *testing.T) {
+	splitter := NewTrafficSplitter(nil)
+
+	// Aynı kullanıcı her zaman aynı versiyona düşer
+	route := newSplitRoute(t, 50, 50)
+	chosen := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		first := splitter.Select(newSplitContext(nil), route, userID).Name
+		for j := 0; j < 3; j++ {
+			if again := splitter.Select(newSplitContext(nil), route, userID).Name; again != first {
+				t.Fatalf("%s farklı versiyonlara düştü: %s, %s", userID, first, again)
+			}
+		}
+		chosen[first]++
+	}
+	if chosen["stable"] < 60 || chosen["canary"] < 60 {
+		t.Errorf("kullanıcılar dengesiz dağıldı: %v", chosen)
+	}
+
+	// Canary ağırlığı artırıldığında mevcut canary kullanıcıları canary'de kalır
+	small, large := newSplitRoute(t, 90, 10), newSplitRoute(t, 70, 30)
+	for i := 0; i < 500; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		if splitter.Select(newSplitContext(nil), small, userID).Name != "canary" {
+			continue
+		}
+		if got := splitter.Select(newSplitContext(nil), large, userID).Name; got != "canary" {
+			t.Fatalf("%s canary ağırlığı artınca %s versiyonuna taşındı", userID, got)
+		}
+	}
+}
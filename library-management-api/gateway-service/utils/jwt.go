@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity doğrulanmış token'dan çıkarılan kullanıcı kimliği
+type Identity struct {
+	UserID   string
+	Username string
+	Email    string
+	Roles    []string
+}
+
+// HasAnyRole kullanıcının verilen rollerden en az birine sahip olup olmadığını kontrol eder
+func (i *Identity) HasAnyRole(roles []string) bool {
+	for _, required := range roles {
+		for _, role := range i.Roles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JWTVerifier auth-service tarafından üretilen JWT token'ları doğrular
+type JWTVerifier struct {
+	secretKey string
+}
+
+// NewJWTVerifier yeni JWT verifier oluşturur
+func NewJWTVerifier(secretKey string) *JWTVerifier {
+	return &JWTVerifier{
+		secretKey: secretKey,
+	}
+}
+
+// VerifyToken JWT token'ı doğrular ve kullanıcı kimliğini döner
+func (v *JWTVerifier) VerifyToken(tokenString string) (*Identity, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Signing method kontrolü
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("beklenmeyen signing method: %v", token.Header["alg"])
+		}
+		return []byte(v.secretKey), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("token parse hatası: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("geçersiz token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("claims parse hatası")
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("geçersiz user_id")
+	}
+
+	username, ok := claims["username"].(string)
+	if !ok {
+		return nil, fmt.Errorf("geçersiz username")
+	}
+
+	email, _ := claims["email"].(string)
+
+	return &Identity{
+		UserID:   strconv.FormatUint(uint64(userID), 10),
+		Username: username,
+		Email:    email,
+		Roles:    parseRoles(claims["roles"]),
+	}, nil
+}
+
+// parseRoles roles claim'ini liste veya virgülle ayrılmış string olarak okur
+func parseRoles(claim interface{}) []string {
+	var roles []string
+	switch value := claim.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if role, ok := item.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	case string:
+		for _, role := range strings.Split(value, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+// ExtractTokenFromHeader Authorization header'ından token'ı çıkarır
+func ExtractTokenFromHeader(authHeader string) (string, error) {
+	if authHeader == "" {
+		return "", fmt.Errorf("authorization header bulunamadı")
+	}
+
+	// "Bearer " prefix kontrolü
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return "", fmt.Errorf("geçersiz authorization header formatı")
+	}
+
+	return strings.TrimSpace(authHeader[len(bearerPrefix):]), nil
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+// signToken claim'leri verilen method ve anahtarla imzalar
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("token imzalanamadı: %v", err)
+	}
+	return token
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"user_id":  float64(42),
+		"username": "mert",
+		"email":    "mert@example.com",
+		"roles":    []interface{}{"admin", "librarian"},
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestJWTVerifierVerifyToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("RSA anahtarı üretilemedi: %v", err)
+	}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "geçerli token", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims())},
+		{name: "farklı secret", token: signToken(t, jwt.SigningMethodHS256, []byte("other"), validClaims()), wantErr: true},
+		{name: "alg none", token: signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims()), wantErr: true},
+		{name: "RS256 ile imzalanmış", token: signToken(t, jwt.SigningMethodRS256, rsaKey, validClaims()), wantErr: true},
+		{name: "süresi dolmuş", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), with("exp", time.Now().Add(-time.Minute).Unix())), wantErr: true},
+		{name: "henüz geçerli değil", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), with("nbf", time.Now().Add(time.Hour).Unix())), wantErr: true},
+		{name: "user_id yok", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), with("user_id", nil)), wantErr: true},
+		{name: "user_id string", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), with("user_id", "42")), wantErr: true},
+		{name: "username yok", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), with("username", nil)), wantErr: true},
+		{name: "bozuk token", token: "a.b.c", wantErr: true},
+	}
+
+	verifier := NewJWTVerifier(testSecret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.VerifyToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("hata = %v, hata bekleniyor = %v", err, tt.wantErr)
+			}
+			if err == nil && (identity.UserID != "42" || identity.Username != "mert" || identity.Email != "mert@example.com") {
+				t.Errorf("kimlik = %+v", identity)
+			}
+		})
+	}
+}
+
+func TestParseRoles(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim interface{}
+		want  string
+	}{
+		{name: "liste", claim: []interface{}{"admin", "", 7, "librarian"}, want: "admin,librarian"},
+		{name: "virgüllü string", claim: " admin, ,librarian ", want: "admin,librarian"},
+		{name: "claim yok", claim: nil, want: ""},
+		{name: "desteklenmeyen tip", claim: 3.0, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(parseRoles(tt.claim), ","); got != tt.want {
+				t.Errorf("roller = %q, beklenen %q", got, tt.want)
+			}
+		})
+	}
+
+	identity := &Identity{Roles: []string{"librarian"}}
+	if !identity.HasAnyRole([]string{"admin", "librarian"}) || identity.HasAnyRole([]string{"admin"}) {
+		t.Error("HasAnyRole rolleri yanlış değerlendirdi")
+	}
+}
+
+func TestExtractTokenFromHeader(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{header: "Bearer abc.def", want: "abc.def"},
+		{header: "bearer  abc.def ", want: "abc.def"},
+		{header: "", wantErr: true},
+		{header: "Basic dXNlcjpwYXNz", wantErr: true},
+		{header: "Bearer", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := ExtractTokenFromHeader(tt.header)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ExtractTokenFromHeader = (%q, %v), beklenen %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -3,13 +3,19 @@ package configs
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config uygulama konfigürasyonu
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Services ServicesConfig `json:"services"`
+	Server    ServerConfig    `json:"server"`
+	Database  DatabaseConfig  `json:"database"`
+	Services  ServicesConfig  `json:"services"`
+	Metrics   MetricsConfig   `json:"metrics"`
+	Tracing   TracingConfig   `json:"tracing"`
+	Shutdown  ShutdownConfig  `json:"shutdown"`
+	Discovery DiscoveryConfig `json:"discovery"`
 }
 
 // ServerConfig server konfigürasyonu
@@ -33,9 +39,38 @@ type ServicesConfig struct {
 	BookServiceURL string `json:"book_service_url"`
 }
 
+// MetricsConfig Prometheus metrics konfigürasyonu
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+}
+
+// TracingConfig dağıtık tracing konfigürasyonu
+type TracingConfig struct {
+	Exporter     string  `json:"exporter"`      // none, stdout, file veya otlp
+	FilePath     string  `json:"file_path"`     // file exporter'ın span'leri yazdığı dosya
+	OTLPEndpoint string  `json:"otlp_endpoint"` // boşsa OTEL_EXPORTER_OTLP_ENDPOINT veya localhost:4318
+	SampleRatio  float64 `json:"sample_ratio"`
+}
+
+// DiscoveryConfig gateway'deki service registry'ye kayıt ve diğer servislerin instance'larını bulma konfigürasyonu
+type DiscoveryConfig struct {
+	RegistryURL       string        `json:"registry_url"`       // registry'yi sunan gateway adresi; boşsa sadece statik servis URL'leri kullanılır
+	RegistryToken     string        `json:"-"`                  // X-Registry-Token
+	AdvertiseURL      string        `json:"advertise_url"`      // diğer servislerin bu instance'a ulaşacağı adres
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"` // registry TTL'inden kısa olmalı
+	RefreshInterval   time.Duration `json:"refresh_interval"`   // diğer servislerin instance listelerinin yenilenme aralığı
+}
+
+// ShutdownConfig kontrollü kapanma konfigürasyonu
+type ShutdownConfig struct {
+	Timeout    time.Duration `json:"timeout"`     // devam eden istekler ve kaynakların kapatılması için beklenecek en uzun süre
+	DrainDelay time.Duration `json:"drain_delay"` // health check DRAINING döndükten sonra bağlantıları kesmeden önce beklenen süre
+}
+
 // LoadConfig konfigürasyonu yükler
 func LoadConfig() *Config {
-	return &Config{
+	cfg := &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "3003"),
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
@@ -51,7 +86,30 @@ func LoadConfig() *Config {
 		Services: ServicesConfig{
 			BookServiceURL: getEnv("BOOK_SERVICE_URL", "http://localhost:3001"),
 		},
+		Metrics: MetricsConfig{
+			Enabled: getEnvBool("METRICS_ENABLED", true),
+			Path:    getEnv("METRICS_PATH", "/metrics"),
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			FilePath:     getEnv("TRACING_FILE", "traces.jsonl"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", ""),
+			SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Shutdown: ShutdownConfig{
+			Timeout:    getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+			DrainDelay: getEnvDuration("SHUTDOWN_DRAIN_DELAY", 0),
+		},
 	}
+
+	cfg.Discovery = DiscoveryConfig{
+		RegistryURL:       getEnv("REGISTRY_URL", ""),
+		RegistryToken:     getEnv("REGISTRY_TOKEN", ""),
+		AdvertiseURL:      getEnv("SERVICE_ADVERTISE_URL", "http://localhost:"+cfg.Server.Port),
+		HeartbeatInterval: getEnvDuration("REGISTRY_HEARTBEAT_INTERVAL", 10*time.Second),
+		RefreshInterval:   getEnvDuration("DISCOVERY_REFRESH_INTERVAL", 10*time.Second),
+	}
+	return cfg
 }
 
 // GetDatabaseURL veritabanı bağlantı string'ini oluşturur
@@ -77,4 +135,31 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvBool true/false environment variable'ını okur, yoksa veya geçersizse default değer döner
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat ondalıklı environment variable'ı okur, yoksa veya geçersizse default değer döner
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration süre environment variable'ını (ör. 10s) okur, yoksa veya geçersizse default değer döner
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"genre-service/internal/model"
+	"genre-service/internal/requestid"
 	"genre-service/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,7 @@ func (h *GenreHandler) GetGenres(c *gin.Context) {
 		return
 	}
 
-	result, err := h.genreService.GetPaginatedGenres(params)
+	result, err := h.genreService.GetPaginatedGenres(c.Request.Context(), params)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "GET_GENRES_ERROR", "Türler getirilemedi")
 		return
@@ -56,9 +57,9 @@ func (h *GenreHandler) GetGenreByID(c *gin.Context) {
 
 	// Mock tür bilgisi (gerçekte DB'den gelecek)
 	genreName := "Tür " + idParam
-	
+
 	// Zenginleştirilmiş tür bilgisini al
-	enrichedGenre, err := h.genreService.GetEnrichedGenreByName(genreName)
+	enrichedGenre, err := h.genreService.GetEnrichedGenreByName(c.Request.Context(), genreName)
 	if err != nil {
 		if err == model.ErrGenreNotFound {
 			h.respondError(c, http.StatusNotFound, "GENRE_NOT_FOUND", "Tür bulunamadı")
@@ -97,7 +98,7 @@ func (h *GenreHandler) GetGenreDetailByName(c *gin.Context) {
 		params.PageSize = 50
 	}
 
-	enrichedGenre, err := h.genreService.GetEnrichedGenreByNameWithPagination(genreName, params)
+	enrichedGenre, err := h.genreService.GetEnrichedGenreByNameWithPagination(c.Request.Context(), genreName, params)
 	if err != nil {
 		if err == model.ErrGenreNotFound {
 			h.respondError(c, http.StatusNotFound, "GENRE_NOT_FOUND", "Tür bulunamadı")
@@ -108,13 +109,13 @@ func (h *GenreHandler) GetGenreDetailByName(c *gin.Context) {
 	}
 
 	h.respondSuccess(c, gin.H{
-		"genre":      enrichedGenre.Genre,
-		"books":      enrichedGenre.Books,
-		"book_count": enrichedGenre.BookCount,
-		"page":       params.Page,
-		"page_size":  params.PageSize,
+		"genre":       enrichedGenre.Genre,
+		"books":       enrichedGenre.Books,
+		"book_count":  enrichedGenre.BookCount,
+		"page":        params.Page,
+		"page_size":   params.PageSize,
 		"total_pages": (enrichedGenre.BookCount + params.PageSize - 1) / params.PageSize,
-		"total":      enrichedGenre.BookCount,
+		"total":       enrichedGenre.BookCount,
 	})
 }
 
@@ -126,7 +127,7 @@ func (h *GenreHandler) SearchGenres(c *gin.Context) {
 		return
 	}
 
-	genres, err := h.genreService.GetGenreByName(name)
+	genres, err := h.genreService.GetGenreByName(c.Request.Context(), name)
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "SEARCH_GENRES_ERROR", "Tür arama başarısız")
 		return
@@ -168,8 +169,9 @@ func (h *GenreHandler) respondSuccess(c *gin.Context, data interface{}) {
 func (h *GenreHandler) respondError(c *gin.Context, statusCode int, errorCode, message string) {
 	c.JSON(statusCode, gin.H{
 		"error": gin.H{
-			"code":    errorCode,
-			"message": message,
+			"code":       errorCode,
+			"message":    message,
+			"request_id": requestid.Get(c),
 		},
 	})
-} 
\ No newline at end of file
+}
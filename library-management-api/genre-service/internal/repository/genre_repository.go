@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -10,8 +11,8 @@ import (
 
 // GenreRepository tür veri erişim interface'i
 type GenreRepository interface {
-	GetPaginatedGenres(params *model.GenreSearchParams) (*model.PaginatedGenres, error)
-	GetGenreByName(name string) ([]model.Genre, error)
+	GetPaginatedGenres(ctx context.Context, params *model.GenreSearchParams) (*model.PaginatedGenres, error)
+	GetGenreByName(ctx context.Context, name string) ([]model.Genre, error)
 	Close() error
 }
 
@@ -28,7 +29,7 @@ func NewPostgreSQLGenreRepository(db *sql.DB) GenreRepository {
 }
 
 // GetPaginatedGenres sayfalı tür listesi getirir
-func (r *PostgreSQLGenreRepository) GetPaginatedGenres(params *model.GenreSearchParams) (*model.PaginatedGenres, error) {
+func (r *PostgreSQLGenreRepository) GetPaginatedGenres(ctx context.Context, params *model.GenreSearchParams) (*model.PaginatedGenres, error) {
 	if params.Page < 1 {
 		params.Page = 1
 	}
@@ -41,7 +42,7 @@ func (r *PostgreSQLGenreRepository) GetPaginatedGenres(params *model.GenreSearch
 	// WHERE şartını hazırla
 	whereClause := ""
 	args := []interface{}{}
-	
+
 	if params.SearchTerm != "" {
 		whereClause = " WHERE LOWER(book_category_name) LIKE LOWER($1)"
 		args = append(args, "%"+params.SearchTerm+"%")
@@ -50,7 +51,7 @@ func (r *PostgreSQLGenreRepository) GetPaginatedGenres(params *model.GenreSearch
 	// Toplam sayıyı al
 	countQuery := `SELECT COUNT(DISTINCT book_category_name) FROM books` + whereClause
 	var total int
-	err := r.db.QueryRow(countQuery, args...).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("toplam genre sayısı sorgulanamadı: %v", err)
 	}
@@ -60,10 +61,10 @@ func (r *PostgreSQLGenreRepository) GetPaginatedGenres(params *model.GenreSearch
 			  FROM books` + whereClause + `
 			  ORDER BY book_category_name 
 			  LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
-	
+
 	args = append(args, params.PageSize, offset)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("genreler sorgulanamadı: %v", err)
 	}
@@ -96,13 +97,13 @@ func (r *PostgreSQLGenreRepository) GetPaginatedGenres(params *model.GenreSearch
 }
 
 // GetGenreByName isim ile genre arama
-func (r *PostgreSQLGenreRepository) GetGenreByName(name string) ([]model.Genre, error) {
+func (r *PostgreSQLGenreRepository) GetGenreByName(ctx context.Context, name string) ([]model.Genre, error) {
 	query := `SELECT DISTINCT book_category_name 
 			  FROM books
 			  WHERE LOWER(book_category_name) LIKE LOWER($1)
 			  ORDER BY book_category_name`
-	
-	rows, err := r.db.Query(query, "%"+name+"%")
+
+	rows, err := r.db.QueryContext(ctx, query, "%"+name+"%")
 	if err != nil {
 		return nil, fmt.Errorf("genre arama sorgulanamadı: %v", err)
 	}
@@ -132,4 +133,4 @@ func (r *PostgreSQLGenreRepository) Close() error {
 		return r.db.Close()
 	}
 	return nil
-} 
\ No newline at end of file
+}
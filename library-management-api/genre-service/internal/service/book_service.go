@@ -1,46 +1,50 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"time"
 
+	"genre-service/internal/discovery"
 	"genre-service/internal/model"
+	"genre-service/internal/requestid"
+	"genre-service/internal/tracing"
 )
 
 // BookService book microservice ile iletişim interface'i
 type BookService interface {
-	GetBooksByCategory(categoryName string) ([]model.BookInfo, error)
-	GetBooksByCategoryWithPagination(categoryName string, page, pageSize int) ([]model.BookInfo, error)
-	GetBookCountByCategory(categoryName string) (int, error)
+	GetBooksByCategory(ctx context.Context, categoryName string) ([]model.BookInfo, error)
+	GetBooksByCategoryWithPagination(ctx context.Context, categoryName string, page, pageSize int) ([]model.BookInfo, error)
+	GetBookCountByCategory(ctx context.Context, categoryName string) (int, error)
 }
 
 // HTTPBookService HTTP üzerinden book service implementasyonu
 type HTTPBookService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-// NewHTTPBookService yeni HTTP book service oluşturur
-func NewHTTPBookService(baseURL string) BookService {
+// NewHTTPBookService yeni HTTP book service oluşturur; her istek endpoint'in çözdüğü instance'a gider
+func NewHTTPBookService(endpoint *discovery.Endpoint) BookService {
 	return &HTTPBookService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
 // GetBooksByCategory book service'den kategori kitaplarını getirir
-func (s *HTTPBookService) GetBooksByCategory(categoryName string) ([]model.BookInfo, error) {
-	url := fmt.Sprintf("%s/api/books/category/%s", s.baseURL, categoryName)
-	
-	log.Printf("Book service'e istek gönderiliyor: %s", url)
-	
-	resp, err := s.httpClient.Get(url)
+func (s *HTTPBookService) GetBooksByCategory(ctx context.Context, categoryName string) ([]model.BookInfo, error) {
+	url := fmt.Sprintf("%s/api/books/category/%s", s.endpoint.URL(), categoryName)
+
+	requestid.Printf(ctx, "Book service'e istek gönderiliyor: %s", url)
+
+	resp, err := s.get(ctx, url)
 	if err != nil {
 		return nil, model.NewGenreError("BOOK_SERVICE_ERROR", "Book service'e bağlanamadı", err)
 	}
@@ -58,7 +62,7 @@ func (s *HTTPBookService) GetBooksByCategory(categoryName string) ([]model.BookI
 	var bookResponse struct {
 		Data []model.BookInfo `json:"data"`
 	}
-	
+
 	if err := json.Unmarshal(body, &bookResponse); err != nil {
 		return nil, model.NewGenreError("BOOK_SERVICE_PARSE_ERROR", "Book service yanıtı parse edilemedi", err)
 	}
@@ -67,12 +71,12 @@ func (s *HTTPBookService) GetBooksByCategory(categoryName string) ([]model.BookI
 }
 
 // GetBooksByCategoryWithPagination book service'den kategori kitaplarını sayfalanmış olarak getirir
-func (s *HTTPBookService) GetBooksByCategoryWithPagination(categoryName string, page, pageSize int) ([]model.BookInfo, error) {
-	url := fmt.Sprintf("%s/api/books/category/%s?page=%d&page_size=%d", s.baseURL, categoryName, page, pageSize)
-	
-	log.Printf("Book service'e sayfalanmış istek gönderiliyor: %s", url)
-	
-	resp, err := s.httpClient.Get(url)
+func (s *HTTPBookService) GetBooksByCategoryWithPagination(ctx context.Context, categoryName string, page, pageSize int) ([]model.BookInfo, error) {
+	url := fmt.Sprintf("%s/api/books/category/%s?page=%d&page_size=%d", s.endpoint.URL(), categoryName, page, pageSize)
+
+	requestid.Printf(ctx, "Book service'e sayfalanmış istek gönderiliyor: %s", url)
+
+	resp, err := s.get(ctx, url)
 	if err != nil {
 		return nil, model.NewGenreError("BOOK_SERVICE_ERROR", "Book service'e bağlanamadı", err)
 	}
@@ -92,7 +96,7 @@ func (s *HTTPBookService) GetBooksByCategoryWithPagination(categoryName string,
 			Books []model.BookInfo `json:"books"`
 		} `json:"data"`
 	}
-	
+
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, model.NewGenreError("BOOK_SERVICE_PARSE_ERROR", "Book service yanıtı parse edilemedi", err)
 	}
@@ -101,13 +105,13 @@ func (s *HTTPBookService) GetBooksByCategoryWithPagination(categoryName string,
 }
 
 // GetBookCountByCategory book service'den kategori kitap sayısını getirir
-func (s *HTTPBookService) GetBookCountByCategory(categoryName string) (int, error) {
+func (s *HTTPBookService) GetBookCountByCategory(ctx context.Context, categoryName string) (int, error) {
 	// Pagination ile 1 sayfa, 1 eleman isteyerek total count'u al (optimize edilmiş)
-	url := fmt.Sprintf("%s/api/books/category/%s?page=1&page_size=1", s.baseURL, categoryName)
-	
-	log.Printf("Book service'e count isteği gönderiliyor: %s", url)
-	
-	resp, err := s.httpClient.Get(url)
+	url := fmt.Sprintf("%s/api/books/category/%s?page=1&page_size=1", s.endpoint.URL(), categoryName)
+
+	requestid.Printf(ctx, "Book service'e count isteği gönderiliyor: %s", url)
+
+	resp, err := s.get(ctx, url)
 	if err != nil {
 		return 0, model.NewGenreError("BOOK_SERVICE_ERROR", "Book service'e bağlanamadı", err)
 	}
@@ -127,10 +131,21 @@ func (s *HTTPBookService) GetBookCountByCategory(categoryName string) (int, erro
 			Total int `json:"total"`
 		} `json:"data"`
 	}
-	
+
 	if err := json.Unmarshal(body, &response); err != nil {
 		return 0, model.NewGenreError("BOOK_SERVICE_PARSE_ERROR", "Book service yanıtı parse edilemedi", err)
 	}
 
 	return response.Data.Total, nil
-} 
\ No newline at end of file
+}
+
+// get istek ID'sini taşıyan GET isteği gönderir
+func (s *HTTPBookService) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	requestid.Inject(ctx, req)
+
+	return s.httpClient.Do(req)
+}
@@ -1,18 +1,19 @@
 package service
 
 import (
-	"log"
+	"context"
 
 	"genre-service/internal/model"
 	"genre-service/internal/repository"
+	"genre-service/internal/requestid"
 )
 
 // GenreService tür iş mantığı interface'i
 type GenreService interface {
-	GetPaginatedGenres(params *model.GenreSearchParams) (*model.PaginatedGenres, error)
-	GetGenreByName(name string) ([]model.Genre, error)
-	GetEnrichedGenreByName(name string) (*model.EnrichedGenre, error)
-	GetEnrichedGenreByNameWithPagination(name string, params *model.GenreSearchParams) (*model.EnrichedGenre, error)
+	GetPaginatedGenres(ctx context.Context, params *model.GenreSearchParams) (*model.PaginatedGenres, error)
+	GetGenreByName(ctx context.Context, name string) ([]model.Genre, error)
+	GetEnrichedGenreByName(ctx context.Context, name string) (*model.EnrichedGenre, error)
+	GetEnrichedGenreByNameWithPagination(ctx context.Context, name string, params *model.GenreSearchParams) (*model.EnrichedGenre, error)
 }
 
 // GenreServiceImpl GenreService implementasyonu
@@ -30,28 +31,28 @@ func NewGenreService(genreRepo repository.GenreRepository, bookService BookServi
 }
 
 // GetPaginatedGenres sayfalı tür listesi getirir
-func (s *GenreServiceImpl) GetPaginatedGenres(params *model.GenreSearchParams) (*model.PaginatedGenres, error) {
+func (s *GenreServiceImpl) GetPaginatedGenres(ctx context.Context, params *model.GenreSearchParams) (*model.PaginatedGenres, error) {
 	// Parametreleri doğrula
 	if err := s.validateSearchParams(params); err != nil {
 		return nil, err
 	}
 
-	return s.genreRepo.GetPaginatedGenres(params)
+	return s.genreRepo.GetPaginatedGenres(ctx, params)
 }
 
 // GetGenreByName isim ile tür arama
-func (s *GenreServiceImpl) GetGenreByName(name string) ([]model.Genre, error) {
+func (s *GenreServiceImpl) GetGenreByName(ctx context.Context, name string) ([]model.Genre, error) {
 	if name == "" {
 		return nil, model.ErrInvalidGenreName
 	}
 
-	return s.genreRepo.GetGenreByName(name)
+	return s.genreRepo.GetGenreByName(ctx, name)
 }
 
 // GetEnrichedGenreByName kitap bilgisiyle zenginleştirilmiş tür getirir
-func (s *GenreServiceImpl) GetEnrichedGenreByName(name string) (*model.EnrichedGenre, error) {
+func (s *GenreServiceImpl) GetEnrichedGenreByName(ctx context.Context, name string) (*model.EnrichedGenre, error) {
 	// Önce tür bilgisini al
-	genres, err := s.GetGenreByName(name)
+	genres, err := s.GetGenreByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -64,9 +65,9 @@ func (s *GenreServiceImpl) GetEnrichedGenreByName(name string) (*model.EnrichedG
 	genre := genres[0]
 
 	// Book service'den bu türün kitaplarını al
-	books, err := s.bookService.GetBooksByCategory(name)
+	books, err := s.bookService.GetBooksByCategory(ctx, name)
 	if err != nil {
-		log.Printf("Tür kitapları alınamadı: %v", err)
+		requestid.Printf(ctx, "Tür kitapları alınamadı: %v", err)
 		// Hata olsa bile tür bilgisini döndür
 		books = []model.BookInfo{}
 	}
@@ -75,9 +76,9 @@ func (s *GenreServiceImpl) GetEnrichedGenreByName(name string) (*model.EnrichedG
 }
 
 // GetEnrichedGenreByNameWithPagination kitap bilgisiyle zenginleştirilmiş tür getirir (sayfalanmış)
-func (s *GenreServiceImpl) GetEnrichedGenreByNameWithPagination(name string, params *model.GenreSearchParams) (*model.EnrichedGenre, error) {
+func (s *GenreServiceImpl) GetEnrichedGenreByNameWithPagination(ctx context.Context, name string, params *model.GenreSearchParams) (*model.EnrichedGenre, error) {
 	// Önce tür bilgisini al
-	genres, err := s.GetGenreByName(name)
+	genres, err := s.GetGenreByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -90,17 +91,17 @@ func (s *GenreServiceImpl) GetEnrichedGenreByNameWithPagination(name string, par
 	genre := genres[0]
 
 	// Book service'den bu türün kitaplarını sayfalanmış olarak al
-	books, err := s.bookService.GetBooksByCategoryWithPagination(name, params.Page, params.PageSize)
+	books, err := s.bookService.GetBooksByCategoryWithPagination(ctx, name, params.Page, params.PageSize)
 	if err != nil {
-		log.Printf("Tür kitapları alınamadı: %v", err)
+		requestid.Printf(ctx, "Tür kitapları alınamadı: %v", err)
 		// Hata olsa bile tür bilgisini döndür
 		books = []model.BookInfo{}
 	}
 
 	// Toplam kitap sayısını al
-	totalBooks, err := s.bookService.GetBookCountByCategory(name)
+	totalBooks, err := s.bookService.GetBookCountByCategory(ctx, name)
 	if err != nil {
-		log.Printf("Tür kitap sayısı alınamadı: %v", err)
+		requestid.Printf(ctx, "Tür kitap sayısı alınamadı: %v", err)
 		totalBooks = len(books)
 	}
 
@@ -115,10 +116,10 @@ func (s *GenreServiceImpl) validateSearchParams(params *model.GenreSearchParams)
 	if params.Page < 1 {
 		return model.ErrInvalidPage
 	}
-	
+
 	if params.PageSize < 1 || params.PageSize > 100 {
 		return model.ErrInvalidPageSize
 	}
 
 	return nil
-} 
\ No newline at end of file
+}
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
 
 	"github.com/gin-gonic/gin"
 	"recommendation-service/configs"
+	"recommendation-service/internal/discovery"
 	"recommendation-service/internal/handler"
+	"recommendation-service/internal/metrics"
+	"recommendation-service/internal/requestid"
+	"recommendation-service/internal/server"
 	"recommendation-service/internal/service"
+	"recommendation-service/internal/tracing"
 	"recommendation-service/pkg/logger"
 )
 
@@ -18,10 +25,23 @@ func main() {
 	// Initialize logger
 	logger := logger.New(cfg.LogLevel)
 
+	// Graceful shutdown - health check reports DRAINING on SIGTERM and in-flight requests are awaited
+	srv := server.New(cfg.Shutdown)
+
+	// Initialize distributed tracing
+	shutdownTracing, err := tracing.Init(context.Background(), "recommendation-service", cfg.Tracing)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	srv.OnShutdown("Tracing", shutdownTracing)
+
+	// Service registry - other services are resolved by name, static URLs are used when REGISTRY_URL is unset
+	discoveryClient := discovery.NewClient("recommendation-service", cfg.Discovery)
+
 	// Initialize services
-	bookService := service.NewBookService(cfg.BookServiceURL)
-	authorService := service.NewAuthorService(cfg.AuthorServiceURL)
-	genreService := service.NewGenreService(cfg.GenreServiceURL)
+	bookService := service.NewBookService(discoveryClient.Endpoint("book-service", cfg.BookServiceURL))
+	authorService := service.NewAuthorService(discoveryClient.Endpoint("author-service", cfg.AuthorServiceURL))
+	genreService := service.NewGenreService(discoveryClient.Endpoint("genre-service", cfg.GenreServiceURL))
 	recommendationService := service.NewRecommendationService(bookService, authorService, genreService)
 
 	// Initialize handlers
@@ -32,10 +52,22 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(requestid.Middleware())
+	router.Use(gin.LoggerWithFormatter(requestid.LogFormatter), gin.Recovery())
+
+	// Prometheus metrics
+	if cfg.MetricsEnabled {
+		serviceMetrics := metrics.New("recommendation-service")
+		router.Use(serviceMetrics.Middleware())
+		router.GET(cfg.MetricsPath, gin.WrapH(serviceMetrics.Handler()))
+	}
 
 	// Health check
-	router.GET("/health", h.HealthCheck)
+	router.GET("/health", srv.Readiness(), h.HealthCheck)
+
+	// Distributed tracing - health check and metrics endpoints are not traced
+	router.Use(tracing.Middleware())
 
 	// API routes - diğer servislerle tutarlılık için /api prefix'i kullan
 	apiRoutes := router.Group("/api")
@@ -52,8 +84,16 @@ func main() {
 		port = cfg.Port
 	}
 
+	// Register with the service registry and send heartbeats - deregistered as soon as shutdown starts
+	registryCtx, stopRegistry := context.WithCancel(context.Background())
+	srv.OnDrain("Service registry deregistration", func(ctx context.Context) error {
+		stopRegistry()
+		return discoveryClient.Deregister(ctx)
+	})
+	go discoveryClient.Start(registryCtx)
+
 	logger.Info("Starting recommendation service on port " + port)
-	if err := router.Run(":" + port); err != nil {
+	if err := srv.Run(&http.Server{Addr: ":" + port, Handler: router}); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
-} 
\ No newline at end of file
+}
@@ -2,6 +2,8 @@ package configs
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -11,17 +13,63 @@ type Config struct {
 	BookServiceURL   string
 	AuthorServiceURL string
 	GenreServiceURL  string
+	MetricsEnabled   bool
+	MetricsPath      string
+	Tracing          TracingConfig
+	Shutdown         ShutdownConfig
+	Discovery        DiscoveryConfig
+}
+
+type TracingConfig struct {
+	Exporter     string // none, stdout, file or otlp
+	FilePath     string
+	OTLPEndpoint string
+	SampleRatio  float64
+}
+
+type DiscoveryConfig struct {
+	RegistryURL       string // gateway serving the service registry; empty disables registration and discovery
+	RegistryToken     string
+	AdvertiseURL      string // address other services use to reach this instance
+	HeartbeatInterval time.Duration
+	RefreshInterval   time.Duration
+}
+
+type ShutdownConfig struct {
+	Timeout    time.Duration // max wait for in-flight requests and resource cleanup
+	DrainDelay time.Duration // wait after health check reports DRAINING before closing listeners
 }
 
 func Load() *Config {
-	return &Config{
+	cfg := &Config{
 		Port:             getEnv("PORT", "3004"),
 		Environment:      getEnv("ENV", "development"),
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
 		BookServiceURL:   getEnv("BOOK_SERVICE_URL", "http://localhost:3001"),
 		AuthorServiceURL: getEnv("AUTHOR_SERVICE_URL", "http://localhost:3002"),
 		GenreServiceURL:  getEnv("GENRE_SERVICE_URL", "http://localhost:3003"),
+		MetricsEnabled:   getEnv("METRICS_ENABLED", "true") == "true",
+		MetricsPath:      getEnv("METRICS_PATH", "/metrics"),
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			FilePath:     getEnv("TRACING_FILE", "traces.jsonl"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", ""),
+			SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Shutdown: ShutdownConfig{
+			Timeout:    getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+			DrainDelay: getEnvDuration("SHUTDOWN_DRAIN_DELAY", 0),
+		},
+	}
+
+	cfg.Discovery = DiscoveryConfig{
+		RegistryURL:       getEnv("REGISTRY_URL", ""),
+		RegistryToken:     getEnv("REGISTRY_TOKEN", ""),
+		AdvertiseURL:      getEnv("SERVICE_ADVERTISE_URL", "http://localhost:"+cfg.Port),
+		HeartbeatInterval: getEnvDuration("REGISTRY_HEARTBEAT_INTERVAL", 10*time.Second),
+		RefreshInterval:   getEnvDuration("DISCOVERY_REFRESH_INTERVAL", 10*time.Second),
 	}
+	return cfg
 }
 
 func getEnv(key, defaultValue string) string {
@@ -29,4 +77,20 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-} 
\ No newline at end of file
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
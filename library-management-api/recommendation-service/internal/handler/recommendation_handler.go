@@ -3,9 +3,11 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"recommendation-service/internal/model"
+	"recommendation-service/internal/requestid"
 	"recommendation-service/internal/service"
 	"recommendation-service/pkg/logger"
 )
@@ -36,12 +38,13 @@ func (h *RecommendationHandler) HealthCheck(c *gin.Context) {
 func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
 	limit := h.getLimit(c)
 
-	recommendations, err := h.service.GetGeneralRecommendations(limit)
+	recommendations, err := h.service.GetGeneralRecommendations(c.Request.Context(), limit)
 	if err != nil {
-		h.logger.Error("Failed to get recommendations: " + err.Error())
+		h.logger.WithRequestID(requestid.Get(c)).Error("Failed to get recommendations: " + err.Error())
 		c.JSON(http.StatusInternalServerError, model.APIResponse{
-			Success: false,
-			Error:   "Failed to get recommendations",
+			Success:   false,
+			Error:     "Failed to get recommendations",
+			RequestID: requestid.Get(c),
 		})
 		return
 	}
@@ -56,12 +59,14 @@ func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
 func (h *RecommendationHandler) GetRecommendationsByCategory(c *gin.Context) {
 	limit := h.getLimit(c)
 
-	recommendations, err := h.service.GetRecommendationsByCategory(limit)
+	// category verilmezse rastgele bir tür seçilir
+	recommendations, err := h.service.GetRecommendationsByCategory(c.Request.Context(), strings.TrimSpace(c.Query("category")), limit)
 	if err != nil {
-		h.logger.Error("Failed to get category recommendations: " + err.Error())
+		h.logger.WithRequestID(requestid.Get(c)).Error("Failed to get category recommendations: " + err.Error())
 		c.JSON(http.StatusInternalServerError, model.APIResponse{
-			Success: false,
-			Error:   "Failed to get category recommendations",
+			Success:   false,
+			Error:     "Failed to get category recommendations",
+			RequestID: requestid.Get(c),
 		})
 		return
 	}
@@ -76,12 +81,13 @@ func (h *RecommendationHandler) GetRecommendationsByCategory(c *gin.Context) {
 func (h *RecommendationHandler) GetRecommendationsByAuthor(c *gin.Context) {
 	limit := h.getLimit(c)
 
-	recommendations, err := h.service.GetRecommendationsByAuthor(limit)
+	recommendations, err := h.service.GetRecommendationsByAuthor(c.Request.Context(), limit)
 	if err != nil {
-		h.logger.Error("Failed to get author recommendations: " + err.Error())
+		h.logger.WithRequestID(requestid.Get(c)).Error("Failed to get author recommendations: " + err.Error())
 		c.JSON(http.StatusInternalServerError, model.APIResponse{
-			Success: false,
-			Error:   "Failed to get author recommendations",
+			Success:   false,
+			Error:     "Failed to get author recommendations",
+			RequestID: requestid.Get(c),
 		})
 		return
 	}
@@ -96,12 +102,13 @@ func (h *RecommendationHandler) GetRecommendationsByAuthor(c *gin.Context) {
 func (h *RecommendationHandler) GetTrendingRecommendations(c *gin.Context) {
 	limit := h.getLimit(c)
 
-	recommendations, err := h.service.GetTrendingRecommendations(limit)
+	recommendations, err := h.service.GetTrendingRecommendations(c.Request.Context(), limit)
 	if err != nil {
-		h.logger.Error("Failed to get trending recommendations: " + err.Error())
+		h.logger.WithRequestID(requestid.Get(c)).Error("Failed to get trending recommendations: " + err.Error())
 		c.JSON(http.StatusInternalServerError, model.APIResponse{
-			Success: false,
-			Error:   "Failed to get trending recommendations",
+			Success:   false,
+			Error:     "Failed to get trending recommendations",
+			RequestID: requestid.Get(c),
 		})
 		return
 	}
@@ -120,4 +127,4 @@ func (h *RecommendationHandler) getLimit(c *gin.Context) int {
 		limit = 10
 	}
 	return limit
-} 
\ No newline at end of file
+}
@@ -39,8 +39,9 @@ type RecommendationResponse struct {
 
 // API Response wrapper
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data"`
-	Message string      `json:"message,omitempty"`
-	Error   string      `json:"error,omitempty"`
-} 
\ No newline at end of file
+	Success   bool        `json:"success"`
+	Data      interface{} `json:"data"`
+	Message   string      `json:"message,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
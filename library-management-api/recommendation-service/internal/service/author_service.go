@@ -1,33 +1,37 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"recommendation-service/internal/discovery"
 	"recommendation-service/internal/model"
+	"recommendation-service/internal/tracing"
 )
 
 type AuthorService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-func NewAuthorService(baseURL string) *AuthorService {
+func NewAuthorService(endpoint *discovery.Endpoint) *AuthorService {
 	return &AuthorService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
+			Timeout:   15 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
-func (s *AuthorService) GetAllAuthors(pageSize int) ([]model.Author, error) {
-	url := fmt.Sprintf("%s/api/authors?page_size=%d", s.baseURL, pageSize)
+func (s *AuthorService) GetAllAuthors(ctx context.Context, pageSize int) ([]model.Author, error) {
+	url := fmt.Sprintf("%s/api/authors?page_size=%d", s.endpoint.URL(), pageSize)
 
-	resp, err := s.httpClient.Get(url)
+	resp, err := getWithContext(ctx, s.httpClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch authors: %w", err)
 	}
@@ -53,4 +57,4 @@ func (s *AuthorService) GetAllAuthors(pageSize int) ([]model.Author, error) {
 	}
 
 	return response.Data.Authors, nil
-} 
\ No newline at end of file
+}
@@ -1,46 +1,52 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"time"
 
+	"recommendation-service/internal/discovery"
 	"recommendation-service/internal/model"
+	"recommendation-service/internal/tracing"
 )
 
 type BookService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-func NewBookService(baseURL string) *BookService {
+func NewBookService(endpoint *discovery.Endpoint) *BookService {
 	return &BookService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
+			Timeout:   15 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
-func (s *BookService) GetAllBooks(pageSize int) ([]model.Book, error) {
-	url := fmt.Sprintf("%s/api/books?page_size=%d", s.baseURL, pageSize)
-	return s.getBooks(url)
+func (s *BookService) GetAllBooks(ctx context.Context, pageSize int) ([]model.Book, error) {
+	url := fmt.Sprintf("%s/api/books?page_size=%d", s.endpoint.URL(), pageSize)
+	return s.getBooks(ctx, url)
 }
 
-func (s *BookService) GetBooksByCategory(category string, pageSize int) ([]model.Book, error) {
-	url := fmt.Sprintf("%s/api/books/category/%s?page_size=%d", s.baseURL, category, pageSize)
-	return s.getBooks(url)
+func (s *BookService) GetBooksByCategory(ctx context.Context, category string, pageSize int) ([]model.Book, error) {
+	// category istemciden gelebildiği için path segmenti olarak escape edilir
+	url := fmt.Sprintf("%s/api/books/category/%s?page_size=%d", s.endpoint.URL(), neturl.PathEscape(category), pageSize)
+	return s.getBooks(ctx, url)
 }
 
-func (s *BookService) GetBooksByAuthor(author string, pageSize int) ([]model.Book, error) {
-	url := fmt.Sprintf("%s/api/books/author/%s?page_size=%d", s.baseURL, author, pageSize)
-	return s.getBooks(url)
+func (s *BookService) GetBooksByAuthor(ctx context.Context, author string, pageSize int) ([]model.Book, error) {
+	url := fmt.Sprintf("%s/api/books/author/%s?page_size=%d", s.endpoint.URL(), author, pageSize)
+	return s.getBooks(ctx, url)
 }
 
-func (s *BookService) getBooks(url string) ([]model.Book, error) {
-	resp, err := s.httpClient.Get(url)
+func (s *BookService) getBooks(ctx context.Context, url string) ([]model.Book, error) {
+	resp, err := getWithContext(ctx, s.httpClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch books: %w", err)
 	}
@@ -86,4 +92,4 @@ func (s *BookService) getBooks(url string) ([]model.Book, error) {
 	}
 
 	return nil, fmt.Errorf("unexpected response format")
-} 
\ No newline at end of file
+}
@@ -1,33 +1,37 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"recommendation-service/internal/discovery"
 	"recommendation-service/internal/model"
+	"recommendation-service/internal/tracing"
 )
 
 type GenreService struct {
-	baseURL    string
+	endpoint   *discovery.Endpoint
 	httpClient *http.Client
 }
 
-func NewGenreService(baseURL string) *GenreService {
+func NewGenreService(endpoint *discovery.Endpoint) *GenreService {
 	return &GenreService{
-		baseURL: baseURL,
+		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
+			Timeout:   15 * time.Second,
+			Transport: tracing.Transport(http.DefaultTransport),
 		},
 	}
 }
 
-func (s *GenreService) GetAllGenres(pageSize int) ([]model.Genre, error) {
-	url := fmt.Sprintf("%s/api/genres?page_size=%d", s.baseURL, pageSize)
+func (s *GenreService) GetAllGenres(ctx context.Context, pageSize int) ([]model.Genre, error) {
+	url := fmt.Sprintf("%s/api/genres?page_size=%d", s.endpoint.URL(), pageSize)
 
-	resp, err := s.httpClient.Get(url)
+	resp, err := getWithContext(ctx, s.httpClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch genres: %w", err)
 	}
@@ -63,4 +67,4 @@ func (s *GenreService) GetAllGenres(pageSize int) ([]model.Genre, error) {
 	}
 
 	return nil, fmt.Errorf("unexpected response format")
-} 
\ No newline at end of file
+}
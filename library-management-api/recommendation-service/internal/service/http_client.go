@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"recommendation-service/internal/requestid"
+)
+
+// getWithContext sends a GET request that carries the caller's request ID to downstream services
+func getWithContext(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	requestid.Inject(ctx, req)
+
+	return client.Do(req)
+}
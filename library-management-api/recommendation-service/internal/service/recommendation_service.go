@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -23,14 +24,14 @@ func NewRecommendationService(bookService *BookService, authorService *AuthorSer
 	}
 }
 
-func (s *RecommendationService) GetGeneralRecommendations(limit int) (*model.RecommendationResponse, error) {
-	books, err := s.bookService.GetAllBooks(100)
+func (s *RecommendationService) GetGeneralRecommendations(ctx context.Context, limit int) (*model.RecommendationResponse, error) {
+	books, err := s.bookService.GetAllBooks(ctx, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch books: %w", err)
 	}
 
 	recommendations := s.generateRecommendations(books, limit, "genel")
-	
+
 	return &model.RecommendationResponse{
 		Recommendations: recommendations,
 		Total:           len(recommendations),
@@ -38,37 +39,40 @@ func (s *RecommendationService) GetGeneralRecommendations(limit int) (*model.Rec
 	}, nil
 }
 
-func (s *RecommendationService) GetRecommendationsByCategory(limit int) (*model.RecommendationResponse, error) {
-	genres, err := s.genreService.GetAllGenres(50)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch genres: %w", err)
-	}
+// GetRecommendationsByCategory returns recommendations from the given category, or from a random genre when category is empty
+func (s *RecommendationService) GetRecommendationsByCategory(ctx context.Context, category string, limit int) (*model.RecommendationResponse, error) {
+	if category == "" {
+		genres, err := s.genreService.GetAllGenres(ctx, 50)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch genres: %w", err)
+		}
 
-	if len(genres) == 0 {
-		return nil, fmt.Errorf("no genres found")
-	}
+		if len(genres) == 0 {
+			return nil, fmt.Errorf("no genres found")
+		}
 
-	// Random genre seç
-	rand.Seed(time.Now().UnixNano())
-	selectedGenre := genres[rand.Intn(len(genres))]
+		// Random genre seç
+		rand.Seed(time.Now().UnixNano())
+		category = genres[rand.Intn(len(genres))].Name
+	}
 
-	books, err := s.bookService.GetBooksByCategory(selectedGenre.Name, 50)
+	books, err := s.bookService.GetBooksByCategory(ctx, category, 50)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch books by category: %w", err)
 	}
 
-	recommendations := s.generateRecommendations(books, limit, fmt.Sprintf("%s kategorisi", selectedGenre.Name))
+	recommendations := s.generateRecommendations(books, limit, fmt.Sprintf("%s kategorisi", category))
 
 	return &model.RecommendationResponse{
 		Recommendations: recommendations,
 		Total:           len(recommendations),
-		Category:        selectedGenre.Name,
+		Category:        category,
 		Timestamp:       time.Now().Format(time.RFC3339),
 	}, nil
 }
 
-func (s *RecommendationService) GetRecommendationsByAuthor(limit int) (*model.RecommendationResponse, error) {
-	authors, err := s.authorService.GetAllAuthors(100)
+func (s *RecommendationService) GetRecommendationsByAuthor(ctx context.Context, limit int) (*model.RecommendationResponse, error) {
+	authors, err := s.authorService.GetAllAuthors(ctx, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch authors: %w", err)
 	}
@@ -81,7 +85,7 @@ func (s *RecommendationService) GetRecommendationsByAuthor(limit int) (*model.Re
 	rand.Seed(time.Now().UnixNano())
 	selectedAuthor := authors[rand.Intn(len(authors))]
 
-	books, err := s.bookService.GetBooksByAuthor(selectedAuthor.Name, 50)
+	books, err := s.bookService.GetBooksByAuthor(ctx, selectedAuthor.Name, 50)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch books by author: %w", err)
 	}
@@ -96,8 +100,8 @@ func (s *RecommendationService) GetRecommendationsByAuthor(limit int) (*model.Re
 	}, nil
 }
 
-func (s *RecommendationService) GetTrendingRecommendations(limit int) (*model.RecommendationResponse, error) {
-	books, err := s.bookService.GetAllBooks(100)
+func (s *RecommendationService) GetTrendingRecommendations(ctx context.Context, limit int) (*model.RecommendationResponse, error) {
+	books, err := s.bookService.GetAllBooks(ctx, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch books: %w", err)
 	}
@@ -116,7 +120,7 @@ func (s *RecommendationService) GetTrendingRecommendations(limit int) (*model.Re
 	}
 
 	recommendations := s.generateRecommendations(recentBooks, limit, "trend")
-	
+
 	return &model.RecommendationResponse{
 		Recommendations: recommendations,
 		Total:           len(recommendations),
@@ -180,4 +184,4 @@ func (s *RecommendationService) getReasons(reasonType string) []string {
 			"Okurken keyif alacağınız kitap",
 		}
 	}
-} 
\ No newline at end of file
+}
@@ -15,6 +15,16 @@ func New(level string) *Logger {
 	}
 }
 
+// WithRequestID returns a logger that tags every line with the given request ID
+func (l *Logger) WithRequestID(requestID string) *Logger {
+	if requestID == "" {
+		return l
+	}
+	return &Logger{
+		Logger: log.New(l.Writer(), l.Prefix()+"[request_id="+requestID+"] ", l.Flags()),
+	}
+}
+
 func (l *Logger) Info(msg string) {
 	l.Logger.Printf("[INFO] %s", msg)
 }
@@ -29,4 +39,4 @@ func (l *Logger) Debug(msg string) {
 
 func (l *Logger) Warn(msg string) {
 	l.Logger.Printf("[WARN] %s", msg)
-} 
\ No newline at end of file
+}
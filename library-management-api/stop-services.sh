@@ -37,12 +37,16 @@ stop_service() {
         
         # PID'in hala çalışıp çalışmadığını kontrol et
         if kill -0 $pid > /dev/null 2>&1; then
-            # Önce SIGTERM gönder (nazikçe durdur)
-            kill $pid
+            # Önce SIGTERM gönder (nazikçe durdur) - servis binary'si "go run"ın alt sürecidir,
+            # sinyal doğrudan ona gönderilir ki devam eden istekler tamamlanıp kaynaklar kapatılsın
+            # ("go run" alt süreç kapanınca kendisi de çıkar)
+            if ! pkill -TERM -P $pid > /dev/null 2>&1; then
+                kill $pid
+            fi
             
-            # 5 saniye bekle
+            # SHUTDOWN_TIMEOUT (varsayılan 10s) + kapanma adımları için 15 saniye bekle
             local count=0
-            while [ $count -lt 5 ] && kill -0 $pid > /dev/null 2>&1; do
+            while [ $count -lt 15 ] && kill -0 $pid > /dev/null 2>&1; do
                 sleep 1
                 count=$((count + 1))
             done
@@ -50,6 +54,7 @@ stop_service() {
             # Hala çalışıyorsa SIGKILL gönder
             if kill -0 $pid > /dev/null 2>&1; then
                 echo -e "${YELLOW}⚠️  $service_name nazikçe durmadı, zorla kapatılıyor...${NC}"
+                pkill -KILL -P $pid > /dev/null 2>&1
                 kill -9 $pid
                 sleep 1
             fi